@@ -2,8 +2,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -11,12 +13,14 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	kxdialog "github.com/ErikKalkoken/fyne-kx/dialog"
 	kxmodal "github.com/ErikKalkoken/fyne-kx/modal"
+	kxshortcut "github.com/ErikKalkoken/fyne-kx/shortcut"
 	kxtheme "github.com/ErikKalkoken/fyne-kx/theme"
 )
 
@@ -39,11 +43,13 @@ func main() {
 		{"IconButton", makeIconButton()},
 		{"Modals", makeModals(w)},
 		{"RowWrap", makeRowWrap()},
+		{"Shortcuts", makeShortcuts(w)},
 		{"Slider", makeSlider()},
 		{"Switch", makeSwitch()},
 		{"TappableIcon", makeTappableIcon()},
 		{"TappableImage", makeTappableImage()},
 		{"TappableLabel", makeTappableLabel()},
+		{"Toast", makeToast(w)},
 		{"ToolbarActionMenu", makeToolbarActionMenu()},
 	}
 	body := container.NewStack()
@@ -71,6 +77,7 @@ func main() {
 					"Dialogs",
 					"Layouts",
 					"Modals",
+					"Shortcuts",
 					"Widgets",
 				}
 				return s
@@ -92,6 +99,7 @@ func main() {
 					"TappableIcon",
 					"TappableImage",
 					"TappableLabel",
+					"Toast",
 					"ToolbarActionMenu",
 				}
 				return s
@@ -160,6 +168,7 @@ func main() {
 }
 
 func makeDialogs(w fyne.Window) fyne.CanvasObject {
+	planets := []string{"Mercury", "Venus", "Earth", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune"}
 	c := container.NewVBox(
 		widget.NewButton("Information Dialog with key handler", func() {
 			d := dialog.NewInformation("Info", "You can close this dialog with the Escape key.", w)
@@ -173,6 +182,155 @@ func makeDialogs(w fyne.Window) fyne.CanvasObject {
 			kxdialog.AddDialogKeyHandler(d, w)
 			d.Show()
 		}),
+		widget.NewButton("Form Dialog with extended key handler", func() {
+			name := widget.NewEntry()
+			email := widget.NewEntry()
+			items := []*widget.FormItem{
+				widget.NewFormItem("Name", name),
+				widget.NewFormItem("Email", email),
+			}
+			d := dialog.NewForm("Form", "Submit", "Cancel", items, func(b bool) {
+				fmt.Printf("Form dialog: %v\n", b)
+			}, w)
+			kxdialog.AddDialogKeyHandlerWithBindings(d, w, map[fyne.KeyName]func(){
+				fyne.KeyF1: func() {
+					dialog.ShowInformation("Help", "Press Enter to submit and Tab to move to the next field.", w)
+				},
+			})
+			kxdialog.SubmitOnEnter(d, name, email)
+			d.Show()
+		}),
+		widget.NewButton("Form Dialog from builder", func() {
+			type settings struct {
+				Name     string    `form:"name"`
+				Retries  int       `form:"retries"`
+				Ratio    float64   `form:"ratio"`
+				Enabled  bool      `form:"enabled"`
+				Planet   string    `form:"planet"`
+				Deadline time.Time `form:"deadline"`
+			}
+			b := kxdialog.NewFormBuilder("Settings", w)
+			b.AddString(kxdialog.FormField[string]{Key: "name", Label: "Name", Validators: []func(string) error{
+				func(s string) error {
+					if s == "" {
+						return fmt.Errorf("please enter a name")
+					}
+					return nil
+				},
+			}})
+			b.AddInt(kxdialog.FormField[int]{Key: "retries", Label: "Retries", Initial: 3, HintText: "Between 0 and 10", Validators: []func(int) error{
+				func(v int) error {
+					if v < 0 || v > 10 {
+						return fmt.Errorf("must be between 0 and 10")
+					}
+					return nil
+				},
+			}})
+			b.AddFloat(kxdialog.FormField[float64]{Key: "ratio", Label: "Ratio", Initial: 0.5})
+			b.AddBool(kxdialog.FormField[bool]{Key: "enabled", Label: "Enabled", Initial: true})
+			b.AddChoice(kxdialog.FormField[string]{Key: "planet", Label: "Planet", Initial: "Earth"}, planets)
+			b.AddDate(kxdialog.FormField[time.Time]{Key: "deadline", Label: "Deadline", Initial: time.Now()})
+			b.Show(func(values kxdialog.FormValues) {
+				var s settings
+				if err := values.Decode(&s); err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				fmt.Printf("Form dialog: %+v\n", s)
+			})
+		}),
+		widget.NewButton("Confirm Dialog with don't ask again", func() {
+			kxdialog.ShowConfirmDontAskAgain("Delete file", "Do you really want to delete this file?", "demo.delete-file", func(b bool) {
+				fmt.Printf("Confirm dialog: %v\n", b)
+			}, w)
+		}),
+		widget.NewButton("Reset don't ask again", func() {
+			kxdialog.ResetDontAskAgain("demo.delete-file")
+		}),
+		widget.NewButton("Wizard", func() {
+			name := widget.NewEntry()
+			name.SetPlaceHolder("Your name")
+			advanced := widget.NewCheck("Show advanced settings", nil)
+			summary := widget.NewLabel("")
+			wiz := kxdialog.NewWizard("Setup", []*kxdialog.WizardPage{
+				{
+					Title:   "Name",
+					Content: container.NewVBox(name, advanced),
+					Validate: func() error {
+						if name.Text == "" {
+							return fmt.Errorf("please enter your name")
+						}
+						summary.SetText(fmt.Sprintf("Welcome, %s!", name.Text))
+						return nil
+					},
+				},
+				{
+					Title:   "Advanced",
+					Content: widget.NewLabel("Advanced settings"),
+					Skip: func() bool {
+						return !advanced.Checked
+					},
+				},
+				{
+					Title:   "Summary",
+					Content: summary,
+				},
+			}, w)
+			wiz.ShowSteps = true
+			wiz.OnFinish = func() {
+				fmt.Println("Wizard finished")
+			}
+			kxdialog.AddDialogKeyHandlerWithBindings(wiz, w, nil)
+			wiz.Resize(fyne.NewSize(400, 250))
+			wiz.Show()
+		}),
+		widget.NewButton("Search Dialog", func() {
+			kxdialog.ShowSearchDialog("Select planet", planets, func(s []string) {
+				fmt.Printf("Search dialog: %v\n", s)
+			}, w)
+		}),
+		widget.NewButton("Search Dialog with multi select", func() {
+			d := kxdialog.NewSearchDialog("Select planets", planets, func(s []string) {
+				fmt.Printf("Search dialog: %v\n", s)
+			}, w)
+			d.Multiple = true
+			d.Show()
+		}),
+	)
+	return c
+}
+
+func makeShortcuts(w fyne.Window) fyne.CanvasObject {
+	r := kxshortcut.ForWindow(w)
+	help := &desktop.CustomShortcut{KeyName: fyne.KeyF1}
+	r.Register(kxshortcut.Shortcut{Shortcut: help, Description: "Show keyboard shortcuts", Action: r.ShowHelp})
+	status := widget.NewLabel("")
+	save := &desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierShortcutDefault}
+	tabs := container.NewAppTabs()
+	for _, name := range []string{"Editor", "Preview"} {
+		name := name
+		r.Register(kxshortcut.Shortcut{
+			Shortcut:    save,
+			Description: "Save " + strings.ToLower(name),
+			Scope:       name,
+			Action: func() {
+				status.SetText(fmt.Sprintf("Saved %s", strings.ToLower(name)))
+			},
+		})
+		tabs.Append(container.NewTabItem(name, widget.NewLabel(fmt.Sprintf(
+			"Press %s to save the %s", kxshortcut.Label(save), strings.ToLower(name),
+		))))
+	}
+	r.BindAppTabs(tabs)
+	c := container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel(fmt.Sprintf("Press %s to show all keyboard shortcuts", kxshortcut.Label(help))),
+			widget.NewButton("Show keyboard shortcuts", r.ShowHelp),
+		),
+		status,
+		nil,
+		nil,
+		tabs,
 	)
 	return c
 }
@@ -191,6 +349,7 @@ func makeModals(w fyne.Window) *fyne.Container {
 				}
 				return nil
 			}, 50, w)
+		m.ShowETA = true
 		m.Start()
 	})
 
@@ -235,5 +394,160 @@ func makeModals(w fyne.Window) *fyne.Container {
 		m.Start()
 	})
 
-	return container.NewVBox(b1, b2, b3, b4)
+	b5 := widget.NewButton("ProgressContextModal", func() {
+		m := kxmodal.NewProgressWithContext(context.Background(), "ProgressContextModal", "Please wait...", func(ctx context.Context, progress binding.Float) error {
+			ticker := time.NewTicker(100 * time.Millisecond)
+			for i := 1; i < 50; i++ {
+				fyne.Do(func() {
+					progress.Set(float64(i))
+				})
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-ticker.C:
+				}
+			}
+			return nil
+		}, 50, w)
+		m.OnError = func(err error) {
+			fmt.Printf("ProgressContextModal: %v\n", err)
+		}
+		m.Start()
+	})
+
+	b6 := widget.NewButton("ProgressInfiniteContextModal", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		m := kxmodal.NewProgressInfiniteWithContext(ctx, "ProgressInfiniteContextModal", "Please wait...", func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+			}
+			return nil
+		}, w)
+		m.OnError = func(err error) {
+			cancel()
+			fmt.Printf("ProgressInfiniteContextModal: %v\n", err)
+		}
+		m.OnSuccess = func() {
+			cancel()
+		}
+		m.Start()
+	})
+
+	b7 := widget.NewButton("Progress", func() {
+		m := kxmodal.New("Progress", "Please wait...", func(ctx context.Context, r *kxmodal.Reporter) error {
+			r.SetMessage("Preparing...")
+			time.Sleep(2 * time.Second)
+			r.SetMessage("Processing...")
+			r.SetInfinite(false)
+			ticker := time.NewTicker(100 * time.Millisecond)
+			for i := 1; i <= 30; i++ {
+				r.SetDetail(fmt.Sprintf("Item %d", i))
+				r.SetValue(float64(i))
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-ticker.C:
+				}
+			}
+			return nil
+		}, w, kxmodal.WithInfinite(), kxmodal.WithRange(0, 30), kxmodal.WithCancelLabel("Stop"), kxmodal.WithETA())
+		m.Start()
+	})
+
+	b8 := widget.NewButton("Tasks", func() {
+		tasks := make([]kxmodal.Task, 0)
+		for i := 1; i <= 5; i++ {
+			n := i * 10
+			tasks = append(tasks, kxmodal.Task{
+				Name: fmt.Sprintf("File %d", i),
+				Max:  float64(n),
+				Action: func(ctx context.Context, r *kxmodal.Reporter) error {
+					for j := 1; j <= n; j++ {
+						select {
+						case <-ctx.Done():
+							return ctx.Err()
+						case <-time.After(50 * time.Millisecond):
+						}
+						r.SetValue(float64(j))
+					}
+					if n == 30 {
+						return fmt.Errorf("file is corrupt")
+					}
+					return nil
+				},
+			})
+		}
+		m := kxmodal.NewTasks("Tasks", "Downloading files...", tasks, w,
+			kxmodal.WithConcurrency(2), kxmodal.WithCancel(), kxmodal.WithCallbacksOnMain())
+		m.OnError = func(err error) {
+			dialog.ShowError(err, w)
+		}
+		m.Start()
+	})
+
+	b9 := widget.NewButton("ProgressResult", func() {
+		m := kxmodal.NewWithResult("ProgressResult", "Counting...", func(ctx context.Context, r *kxmodal.Reporter) (int, error) {
+			var n int
+			for i := 1; i <= 20; i++ {
+				time.Sleep(100 * time.Millisecond)
+				n += i
+				r.SetValue(float64(i))
+			}
+			return n, nil
+		}, w, kxmodal.WithRange(0, 20), kxmodal.WithCallbacksOnMain())
+		m.OnSuccess = func(n int) {
+			dialog.ShowInformation("Result", fmt.Sprintf("The sum is %d", n), w)
+		}
+		m.Start()
+	})
+
+	var isSlow bool
+	b10 := widget.NewButton("Progress with delayed show", func() {
+		isSlow = !isSlow
+		d := 200 * time.Millisecond
+		if isSlow {
+			d = 1200 * time.Millisecond
+		}
+		m := kxmodal.New("Progress with delayed show", "Please wait...", func(ctx context.Context, r *kxmodal.Reporter) error {
+			time.Sleep(d)
+			return nil
+		}, w, kxmodal.WithInfinite(), kxmodal.WithShowDelay(500*time.Millisecond), kxmodal.WithMinDisplay(time.Second))
+		m.Start()
+	})
+
+	b11 := widget.NewButton("Progress with retry", func() {
+		var attempts int
+		m := kxmodal.New("Progress with retry", "Connecting...", func(ctx context.Context, r *kxmodal.Reporter) error {
+			time.Sleep(time.Second)
+			attempts++
+			if attempts < 3 {
+				return fmt.Errorf("connection refused")
+			}
+			return nil
+		}, w, kxmodal.WithInfinite(), kxmodal.WithCancel(), kxmodal.WithRetry(kxmodal.RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     kxmodal.ExponentialBackoff(500*time.Millisecond, 5*time.Second),
+		}))
+		m.Start()
+	})
+
+	panel := widget.NewLabel("This panel is covered while loading.\nThe rest of the window stays interactive.")
+	overlay := kxmodal.NewOverlay(container.NewPadded(panel), "Loading...", func(ctx context.Context, r *kxmodal.Reporter) error {
+		for i := 1; i <= 30; i++ {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+			r.SetValue(float64(i))
+		}
+		return nil
+	}, kxmodal.WithRange(0, 30), kxmodal.WithCancel())
+	b12 := widget.NewButton("Overlay", func() {
+		overlay.Start()
+	})
+
+	return container.NewVBox(b1, b2, b3, b4, b5, b6, b7, b8, b9, b10, b11, b12, overlay)
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -126,6 +128,36 @@ func makeTappableLabel() fyne.CanvasObject {
 	return container.NewHBox(label, widget.NewLabel("<- tap"))
 }
 
+func makeToast(w fyne.Window) fyne.CanvasObject {
+	c := container.NewVBox(
+		widget.NewButton("Simple toast", func() {
+			kxwidget.ShowToast(kxwidget.Toast{Text: "Saved"}, w)
+		}),
+		widget.NewButton("Toast with importance", func() {
+			kxwidget.ShowToast(kxwidget.Toast{
+				Text:       "Connection lost",
+				Importance: widget.DangerImportance,
+			}, w)
+		}),
+		widget.NewButton("Toast with action", func() {
+			kxwidget.ShowToast(kxwidget.Toast{
+				Text:        "Item deleted",
+				ActionLabel: "Undo",
+				OnAction: func() {
+					kxwidget.ShowToast(kxwidget.Toast{Text: "Item restored", Importance: widget.SuccessImportance}, w)
+				},
+				Timeout: 5 * time.Second,
+			}, w)
+		}),
+		widget.NewButton("Several toasts", func() {
+			for i := 1; i <= 3; i++ {
+				kxwidget.ShowToast(kxwidget.Toast{Text: fmt.Sprintf("Toast #%d", i), Timeout: time.Second}, w)
+			}
+		}),
+	)
+	return c
+}
+
 func makeToolbarActionMenu() fyne.CanvasObject {
 	menu := kxwidget.NewToolbarActionMenu(theme.MenuIcon(), fyne.NewMenu(
 		"",
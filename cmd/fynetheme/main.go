@@ -1,15 +1,22 @@
 // Fynetheme is a Fyne app for showing details about the default Fyne theme.
+//
+// The path of a theme definition file can be given as argument for previewing it:
+//
+//	fynetheme mytheme.toml
 package main
 
 import (
 	"fmt"
 	"image/color"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -24,28 +31,64 @@ const (
 func main() {
 	app := app.New()
 	w := app.NewWindow("Theme Insight")
+
+	// An optional theme definition file can be given as argument for previewing it.
+	var path string
+	if len(os.Args) > 1 {
+		path = os.Args[1]
+	}
+	var base fyne.Theme
+	variant := "Auto"
+	contrast, updateContrast := makeContrast()
+	applyTheme := func() {
+		th := base
+		if th == nil {
+			th = theme.DefaultTheme()
+		}
+		updateContrast(th)
+		switch variant {
+		case "Light":
+			th = kxtheme.NewBuilder(th).FixedVariant(theme.VariantLight).Build()
+		case "Dark":
+			th = kxtheme.NewBuilder(th).FixedVariant(theme.VariantDark).Build()
+		}
+		app.Settings().SetTheme(th)
+	}
+	loadTheme := func() {
+		th, err := kxtheme.LoadFile(path)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		base = th
+		applyTheme()
+	}
+	if path != "" {
+		w.SetTitle(fmt.Sprintf("Theme Insight - %s", filepath.Base(path)))
+		loadTheme()
+	}
+
 	tabs := container.NewAppTabs(
 		container.NewTabItem("Colors", makeColors()),
 		container.NewTabItem("Icons", makeIcons()),
 		container.NewTabItem("Sizes", makeSizes()),
+		container.NewTabItem("Contrast", contrast),
 	)
 	tabs.SetTabLocation(container.TabLocationLeading)
 
+	reload := widget.NewButtonWithIcon("Reload", theme.ViewRefreshIcon(), loadTheme)
+	if path == "" {
+		reload.Hide()
+	}
 	theme := widget.NewSelect([]string{"Auto", "Light", "Dark"}, func(s string) {
-		switch s {
-		case "Light":
-			app.Settings().SetTheme(kxtheme.DefaultWithFixedVariant(theme.VariantLight))
-		case "Dark":
-			app.Settings().SetTheme(kxtheme.DefaultWithFixedVariant(theme.VariantDark))
-		default:
-			app.Settings().SetTheme(theme.DefaultTheme())
-		}
-
+		variant = s
+		applyTheme()
 	})
-	theme.SetSelected("Auto")
+	theme.SetSelected("Auto") // also applies the theme
 	bottom := container.NewVBox(
 		widget.NewSeparator(),
 		container.NewHBox(
+			reload,
 			layout.NewSpacer(),
 			widget.NewLabel("Theme"),
 			theme,
@@ -137,6 +180,7 @@ func makeColors() fyne.CanvasObject {
 			if id >= len(colorsFiltered) {
 				return
 			}
+			th := theme.Current() // the theme can change while the app is running
 			myColor := colorsFiltered[id]
 			row := co.(*fyne.Container).Objects
 
@@ -201,6 +245,97 @@ func makeColors() fyne.CanvasObject {
 	)
 }
 
+// makeContrast returns the contrast report and a function for updating it with a new theme.
+// The report is created for the theme without fixed variant, so that both variants are checked.
+func makeContrast() (fyne.CanvasObject, func(th fyne.Theme)) {
+	var checks, checksFiltered []kxtheme.ContrastCheck
+	var current fyne.Theme
+	list := widget.NewList(
+		func() int {
+			return len(checksFiltered)
+		},
+		func() fyne.CanvasObject {
+			sample := canvas.NewText("Sample", color.Black)
+			sample.TextStyle.Bold = true
+			bg := canvas.NewRectangle(color.Transparent)
+			bg.SetMinSize(fyne.NewSize(80, 30))
+			return container.NewHBox(
+				widget.NewLabel("Template"),
+				layout.NewSpacer(),
+				container.NewStack(bg, container.NewCenter(sample)),
+				widget.NewLabel("21.00:1"),
+				widget.NewIcon(theme.ConfirmIcon()),
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(checksFiltered) {
+				return
+			}
+			c := checksFiltered[id]
+			row := co.(*fyne.Container).Objects
+
+			label := row[0].(*widget.Label)
+			variant := "Light"
+			if c.Variant == theme.VariantDark {
+				variant = "Dark"
+			}
+			label.SetText(fmt.Sprintf("%s on %s (%s)", c.Foreground, c.Background, variant))
+
+			box := row[2].(*fyne.Container).Objects
+			bg := box[0].(*canvas.Rectangle)
+			bg.FillColor = current.Color(c.Background, c.Variant)
+			bg.Refresh()
+			sample := box[1].(*fyne.Container).Objects[0].(*canvas.Text)
+			sample.Color = current.Color(c.Foreground, c.Variant)
+			sample.Refresh()
+
+			ratio := row[3].(*widget.Label)
+			ratio.SetText(fmt.Sprintf("%.2f:1", c.Ratio))
+
+			icon := row[4].(*widget.Icon)
+			if c.Passed() {
+				icon.SetResource(theme.NewSuccessThemedResource(theme.ConfirmIcon()))
+			} else {
+				icon.SetResource(theme.NewErrorThemedResource(theme.CancelIcon()))
+			}
+		},
+	)
+	summary := widget.NewLabel("")
+	var currentSelection string
+	updateChecksFiltered := func() {
+		checksFiltered = make([]kxtheme.ContrastCheck, 0)
+		var failures int
+		for _, c := range checks {
+			if !c.Passed() {
+				failures++
+			} else if currentSelection == "Failures" {
+				continue
+			}
+			checksFiltered = append(checksFiltered, c)
+		}
+		summary.SetText(fmt.Sprintf("%d of %d checks failed", failures, len(checks)))
+		list.Refresh()
+	}
+	filter := widget.NewSelect([]string{"All", "Failures"}, func(s string) {
+		currentSelection = s
+		updateChecksFiltered()
+	})
+	filter.SetSelected("All")
+	update := func(th fyne.Theme) {
+		current = th
+		checks = kxtheme.CheckContrast(th).Checks
+		updateChecksFiltered()
+	}
+	c := container.NewBorder(
+		container.NewBorder(nil, nil, nil, filter, summary),
+		nil,
+		nil,
+		nil,
+		list,
+	)
+	return c, update
+}
+
 type sizeRow struct {
 	label string
 	name  fyne.ThemeSizeName
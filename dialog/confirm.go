@@ -0,0 +1,61 @@
+package dialog
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// dontAskAgainPrefix is the prefix of the preference keys for remembered choices.
+// It keeps the keys apart from the app's own preferences.
+const dontAskAgainPrefix = "fyne-kx.dontAskAgain."
+
+// ShowConfirmDontAskAgain shows a confirm dialog with a "Don't ask again" check.
+// It is meant for confirming destructive actions, where users can opt out of future prompts.
+//
+// When the user confirms the dialog with the check enabled, the choice is stored for key in the app's preferences.
+// The preference key is prefixed, so it does not collide with the app's own preferences.
+// Subsequent calls with the same key do not show the dialog and call callback with true right away.
+// Remembered choices can be reset with [ResetDontAskAgain].
+func ShowConfirmDontAskAgain(title, message, key string, callback func(bool), parent fyne.Window) {
+	ShowCustomConfirmDontAskAgain(title, lang.L("Yes"), lang.L("No"), message, key, callback, parent)
+}
+
+// ShowCustomConfirmDontAskAgain is like [ShowConfirmDontAskAgain], but with custom labels for the buttons.
+func ShowCustomConfirmDontAskAgain(title, confirm, dismiss, message, key string, callback func(bool), parent fyne.Window) {
+	if fyne.CurrentApp().Preferences().BoolWithFallback(dontAskAgainPrefix+key, false) {
+		if callback != nil {
+			callback(true)
+		}
+		return
+	}
+	d, _ := newConfirmDontAskAgain(title, confirm, dismiss, message, key, callback, parent)
+	d.Show()
+}
+
+// ResetDontAskAgain resets the remembered choices for keys,
+// so the related confirm dialogs are shown again.
+func ResetDontAskAgain(keys ...string) {
+	p := fyne.CurrentApp().Preferences()
+	for _, k := range keys {
+		p.RemoveValue(dontAskAgainPrefix + k)
+	}
+}
+
+func newConfirmDontAskAgain(title, confirm, dismiss, message, key string, callback func(bool), parent fyne.Window) (*dialog.ConfirmDialog, *widget.Check) {
+	label := widget.NewLabel(message)
+	label.Wrapping = fyne.TextWrapWord
+	check := widget.NewCheck(lang.L("Don't ask again"), nil)
+	d := dialog.NewCustomConfirm(title, confirm, dismiss, container.NewVBox(label, check), func(confirmed bool) {
+		if confirmed && check.Checked {
+			fyne.CurrentApp().Preferences().SetBool(dontAskAgainPrefix+key, true)
+		}
+		if callback != nil {
+			callback(confirmed)
+		}
+	}, parent)
+	d.SetConfirmImportance(widget.DangerImportance)
+	return d, check
+}
@@ -0,0 +1,89 @@
+package dialog
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmDontAskAgain(t *testing.T) {
+	a := test.NewTempApp(t)
+	const key = "delete-file"
+	t.Run("should remember choice when confirmed with check", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed bool
+		d, check := newConfirmDontAskAgain("Title", "Yes", "No", "Message", key, func(b bool) {
+			confirmed = b
+		}, w)
+		d.Show()
+		check.SetChecked(true)
+		d.Confirm()
+		assert.True(t, confirmed)
+		assert.True(t, a.Preferences().Bool(dontAskAgainPrefix+key))
+		ResetDontAskAgain(key)
+	})
+	t.Run("should not remember choice when declined", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d, check := newConfirmDontAskAgain("Title", "Yes", "No", "Message", key, nil, w)
+		d.Show()
+		check.SetChecked(true)
+		d.Dismiss()
+		assert.False(t, a.Preferences().Bool(dontAskAgainPrefix+key))
+	})
+	t.Run("should not remember choice without check", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d, _ := newConfirmDontAskAgain("Title", "Yes", "No", "Message", key, nil, w)
+		d.Show()
+		d.Confirm()
+		assert.False(t, a.Preferences().Bool(dontAskAgainPrefix+key))
+	})
+	t.Run("should skip dialog when choice was remembered", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		a.Preferences().SetBool(dontAskAgainPrefix+key, true)
+		var confirmed bool
+		ShowConfirmDontAskAgain("Title", "Message", key, func(b bool) {
+			confirmed = b
+		}, w)
+		assert.True(t, confirmed)
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		ResetDontAskAgain(key)
+	})
+	t.Run("should show dialog again after reset", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		a.Preferences().SetBool(dontAskAgainPrefix+key, true)
+		ResetDontAskAgain(key)
+		var called bool
+		ShowConfirmDontAskAgain("Title", "Message", key, func(bool) {
+			called = true
+		}, w)
+		assert.False(t, called)
+		assert.NotNil(t, w.Canvas().Overlays().Top())
+	})
+	t.Run("should not use the key of an app preference", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		a.Preferences().SetBool(key, true)
+		ShowConfirmDontAskAgain("Title", "Message", key, nil, w)
+		assert.NotNil(t, w.Canvas().Overlays().Top())
+		a.Preferences().RemoveValue(key)
+	})
+	t.Run("can show dialog with custom labels", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		ShowCustomConfirmDontAskAgain("Title", "Delete", "Cancel", "Message", key, nil, w)
+		var labels []string
+		for _, o := range test.LaidOutObjects(w.Canvas().Overlays().Top()) {
+			if b, ok := o.(*widget.Button); ok {
+				labels = append(labels, b.Text)
+			}
+		}
+		assert.ElementsMatch(t, []string{"Delete", "Cancel"}, labels)
+	})
+}
@@ -0,0 +1,369 @@
+package dialog
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+)
+
+// FormField describes a field of a form dialog created by a [FormBuilder].
+type FormField[T any] struct {
+	// Key of the field's value in [FormValues] and the name used in struct tags.
+	Key string
+
+	// Label shown next to the input widget.
+	Label string
+
+	// Optional hint shown below the input widget.
+	HintText string
+
+	// Initial value of the field.
+	Initial T
+
+	// Validators are called in order with the current value of the field.
+	// The form can only be submitted when all validators of all fields return nil.
+	Validators []func(v T) error
+}
+
+func (f FormField[T]) validate(v T) error {
+	for _, validate := range f.Validators {
+		if err := validate(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormValues are the values of a submitted form dialog by field key.
+// The value types are: string for string and choice fields, int, float64, bool and [time.Time].
+type FormValues map[string]any
+
+// Decode copies the values into the fields of the struct pointed to by v.
+// Struct fields are matched by their "form" tag, e.g. `form:"name"`,
+// and fields without a tag are ignored.
+// Values can be stored in fields of a related type, when they fit into it,
+// e.g. an int value in an int64 field or in an int8 field when it is between -128 and 127.
+// Decode returns an error for conversions, which would change the value, e.g. a float64 value into an int field.
+func (fv FormValues) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("form values: decode target must be a pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		key, ok := sf.Tag.Lookup("form")
+		if !ok || key == "-" || !sf.IsExported() {
+			continue
+		}
+		x, ok := fv[key]
+		if !ok {
+			continue
+		}
+		value := reflect.ValueOf(x)
+		field := rv.Field(i)
+		switch {
+		case value.Type().AssignableTo(field.Type()):
+			field.Set(value)
+		case canConvertValue(value, field.Type()):
+			field.Set(value.Convert(field.Type()))
+		default:
+			return fmt.Errorf("form values: can not decode %s value %v of %q into field %s of type %s", value.Type(), x, key, sf.Name, field.Type())
+		}
+	}
+	return nil
+}
+
+// kindFamily returns the family of kinds, which can be converted into each other without changing the meaning.
+func kindFamily(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return k
+}
+
+// canConvertValue reports whether value can be converted to type t without losing information.
+// Only conversions within the same family of kinds are allowed, e.g. from int to int64,
+// and the value must fit into t.
+func canConvertValue(value reflect.Value, t reflect.Type) bool {
+	if !value.Type().ConvertibleTo(t) || kindFamily(value.Kind()) != kindFamily(t.Kind()) {
+		return false
+	}
+	z := reflect.Zero(t)
+	switch kindFamily(t.Kind()) {
+	case reflect.Int:
+		return !z.OverflowInt(value.Int())
+	case reflect.Uint:
+		return !z.OverflowUint(value.Uint())
+	case reflect.Float64:
+		return !z.OverflowFloat(value.Float())
+	}
+	return true
+}
+
+// FormBuilder builds form dialogs from typed field descriptions.
+//
+// Each field is rendered with a matching input widget and its value is validated with the field's validators.
+// The values of a submitted form are returned as [FormValues],
+// which can also be decoded into a struct:
+//
+//	type Settings struct {
+//		Name    string `form:"name"`
+//		Retries int    `form:"retries"`
+//	}
+//
+//	b := kxdialog.NewFormBuilder("Settings", w)
+//	b.AddString(kxdialog.FormField[string]{Key: "name", Label: "Name", Initial: s.Name})
+//	b.AddInt(kxdialog.FormField[int]{Key: "retries", Label: "Retries", Initial: s.Retries})
+//	b.Show(func(values kxdialog.FormValues) {
+//		if err := values.Decode(&s); err != nil {
+//			dialog.ShowError(err, w)
+//		}
+//	})
+type FormBuilder struct {
+	// Label of the button for submitting the form.
+	ConfirmLabel string
+
+	// Label of the button for canceling the form.
+	DismissLabel string
+
+	fields []*formField
+	parent fyne.Window
+	title  string
+}
+
+// formField is a field added to a form builder.
+type formField struct {
+	item  *widget.FormItem
+	key   string
+	value func() any
+}
+
+// NewFormBuilder returns a new [FormBuilder] for a form dialog with title.
+func NewFormBuilder(title string, parent fyne.Window) *FormBuilder {
+	b := &FormBuilder{
+		ConfirmLabel: "Submit",
+		DismissLabel: "Cancel",
+		parent:       parent,
+		title:        title,
+	}
+	return b
+}
+
+// AddString adds a field for entering text.
+func (b *FormBuilder) AddString(f FormField[string]) *FormBuilder {
+	e := widget.NewEntry()
+	e.SetText(f.Initial)
+	e.Validator = f.validate
+	b.add(f.Key, f.Label, f.HintText, e, func() any {
+		return e.Text
+	})
+	return b
+}
+
+// AddInt adds a field for entering an integer number.
+func (b *FormBuilder) AddInt(f FormField[int]) *FormBuilder {
+	e := widget.NewEntry()
+	e.SetText(strconv.Itoa(f.Initial))
+	parse := func(s string) (int, error) {
+		v, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return 0, errors.New("not a whole number")
+		}
+		return v, nil
+	}
+	e.Validator = func(s string) error {
+		v, err := parse(s)
+		if err != nil {
+			return err
+		}
+		return f.validate(v)
+	}
+	b.add(f.Key, f.Label, f.HintText, e, func() any {
+		v, _ := parse(e.Text)
+		return v
+	})
+	return b
+}
+
+// AddFloat adds a field for entering a number.
+func (b *FormBuilder) AddFloat(f FormField[float64]) *FormBuilder {
+	e := widget.NewEntry()
+	e.SetText(strconv.FormatFloat(f.Initial, 'f', -1, 64))
+	parse := func(s string) (float64, error) {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return 0, errors.New("not a number")
+		}
+		return v, nil
+	}
+	e.Validator = func(s string) error {
+		v, err := parse(s)
+		if err != nil {
+			return err
+		}
+		return f.validate(v)
+	}
+	b.add(f.Key, f.Label, f.HintText, e, func() any {
+		v, _ := parse(e.Text)
+		return v
+	})
+	return b
+}
+
+// AddBool adds a field for switching an option on or off. It is rendered as [kxwidget.Switch].
+func (b *FormBuilder) AddBool(f FormField[bool]) *FormBuilder {
+	sw := kxwidget.NewSwitch(nil)
+	sw.On = f.Initial
+	v := newValidatedInput(container.NewHBox(sw), func() error {
+		return f.validate(sw.On)
+	})
+	sw.OnChanged = func(bool) {
+		v.changed()
+	}
+	b.add(f.Key, f.Label, f.HintText, v, func() any {
+		return sw.On
+	})
+	return b
+}
+
+// AddChoice adds a field for choosing one of options. It is rendered as [kxwidget.FilterChipSelect].
+// The value is an empty string when no option is chosen.
+func (b *FormBuilder) AddChoice(f FormField[string], options []string) *FormBuilder {
+	chip := kxwidget.NewFilterChipSelect("Select", options, nil)
+	chip.SortDisabled = true
+	chip.Selected = f.Initial
+	v := newValidatedInput(container.NewHBox(chip), func() error {
+		return f.validate(chip.Selected)
+	})
+	chip.OnChanged = func(string) {
+		v.changed()
+	}
+	b.add(f.Key, f.Label, f.HintText, v, func() any {
+		return chip.Selected
+	})
+	return b
+}
+
+// AddDate adds a field for entering a date.
+// The value is the zero time when no date is entered.
+func (b *FormBuilder) AddDate(f FormField[time.Time]) *FormBuilder {
+	e := widget.NewDateEntry()
+	if !f.Initial.IsZero() {
+		e.SetDate(&f.Initial)
+	}
+	date := func() time.Time {
+		if e.Date == nil {
+			return time.Time{}
+		}
+		return *e.Date
+	}
+	v := newValidatedInput(e, func() error {
+		if e.Text != "" && e.Validate() != nil {
+			return errors.New("not a valid date")
+		}
+		return f.validate(date())
+	})
+	e.OnChanged = func(*time.Time) {
+		v.changed()
+	}
+	e.SetOnValidationChanged(func(error) {
+		v.changed()
+	})
+	b.add(f.Key, f.Label, f.HintText, v, func() any {
+		return date()
+	})
+	return b
+}
+
+func (b *FormBuilder) add(key, label, hint string, o fyne.CanvasObject, value func() any) {
+	item := widget.NewFormItem(label, o)
+	item.HintText = hint
+	b.fields = append(b.fields, &formField{item: item, key: key, value: value})
+}
+
+// Build returns a new form dialog with all fields.
+// The callback onSubmit is called with the values of all fields when the user submits the form.
+func (b *FormBuilder) Build(onSubmit func(values FormValues)) *dialog.FormDialog {
+	items := make([]*widget.FormItem, len(b.fields))
+	for i, f := range b.fields {
+		items[i] = f.item
+	}
+	d := dialog.NewForm(b.title, b.ConfirmLabel, b.DismissLabel, items, func(confirmed bool) {
+		if !confirmed || onSubmit == nil {
+			return
+		}
+		values := make(FormValues)
+		for _, f := range b.fields {
+			values[f.key] = f.value()
+		}
+		onSubmit(values)
+	}, b.parent)
+	return d
+}
+
+// Show builds and shows a new form dialog with all fields.
+// The callback onSubmit is called with the values of all fields when the user submits the form.
+func (b *FormBuilder) Show(onSubmit func(values FormValues)) {
+	b.Build(onSubmit).Show()
+}
+
+// validatedInput adds validation to an input widget, which does not support validation itself,
+// so it can be validated by a form.
+type validatedInput struct {
+	widget.BaseWidget
+
+	content             fyne.CanvasObject
+	err                 error
+	onValidationChanged func(error)
+	validate            func() error
+}
+
+var _ fyne.Validatable = (*validatedInput)(nil)
+
+func newValidatedInput(content fyne.CanvasObject, validate func() error) *validatedInput {
+	w := &validatedInput{content: content, validate: validate}
+	w.ExtendBaseWidget(w)
+	w.err = validate()
+	return w
+}
+
+func (w *validatedInput) Validate() error {
+	w.err = w.validate()
+	return w.err
+}
+
+func (w *validatedInput) SetOnValidationChanged(callback func(error)) {
+	w.onValidationChanged = callback
+}
+
+// changed validates the input again after its value has changed.
+func (w *validatedInput) changed() {
+	err := w.validate()
+	if err == w.err || err != nil && w.err != nil && err.Error() == w.err.Error() {
+		return
+	}
+	w.err = err
+	if w.onValidationChanged != nil {
+		w.onValidationChanged(err)
+	}
+}
+
+func (w *validatedInput) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(w.content)
+}
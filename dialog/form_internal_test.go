@@ -0,0 +1,201 @@
+package dialog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+)
+
+func TestFormBuilder(t *testing.T) {
+	test.NewTempApp(t)
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	notEmpty := func(s string) error {
+		if s == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	}
+	t.Run("should return initial values when submitted", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		b := NewFormBuilder("Title", w)
+		b.AddString(FormField[string]{Key: "name", Label: "Name", Initial: "Alice"})
+		b.AddInt(FormField[int]{Key: "age", Label: "Age", Initial: 42})
+		b.AddFloat(FormField[float64]{Key: "height", Label: "Height", Initial: 1.75})
+		b.AddBool(FormField[bool]{Key: "active", Label: "Active", Initial: true})
+		b.AddChoice(FormField[string]{Key: "color", Label: "Color", Initial: "Blue"}, []string{"Red", "Blue"})
+		b.AddDate(FormField[time.Time]{Key: "birthday", Label: "Birthday", Initial: date})
+		var got FormValues
+		d := b.Build(func(values FormValues) {
+			got = values
+		})
+		d.Show()
+		d.Submit()
+		assert.Equal(t, FormValues{
+			"name":     "Alice",
+			"age":      42,
+			"height":   1.75,
+			"active":   true,
+			"color":    "Blue",
+			"birthday": date,
+		}, got)
+	})
+	t.Run("should return changed values when submitted", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		b := NewFormBuilder("Title", w)
+		b.AddString(FormField[string]{Key: "name", Label: "Name"})
+		b.AddInt(FormField[int]{Key: "age", Label: "Age"})
+		b.AddFloat(FormField[float64]{Key: "height", Label: "Height"})
+		b.AddBool(FormField[bool]{Key: "active", Label: "Active"})
+		b.AddChoice(FormField[string]{Key: "color", Label: "Color"}, []string{"Red", "Blue"})
+		var got FormValues
+		d := b.Build(func(values FormValues) {
+			got = values
+		})
+		d.Show()
+		test.Type(b.fields[0].item.Widget.(*widget.Entry), "Bob")
+		b.fields[1].item.Widget.(*widget.Entry).SetText(" 7 ")
+		b.fields[2].item.Widget.(*widget.Entry).SetText("2.5")
+		input := b.fields[3].item.Widget.(*validatedInput)
+		test.Tap(input.content.(*fyne.Container).Objects[0].(*kxwidget.Switch))
+		input = b.fields[4].item.Widget.(*validatedInput)
+		input.content.(*fyne.Container).Objects[0].(*kxwidget.FilterChipSelect).SetSelected("Red")
+		d.Submit()
+		assert.Equal(t, FormValues{
+			"name":   "Bob",
+			"age":    7,
+			"height": 2.5,
+			"active": true,
+			"color":  "Red",
+		}, got)
+	})
+	t.Run("should not call callback when canceled", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		b := NewFormBuilder("Title", w)
+		b.AddString(FormField[string]{Key: "name", Label: "Name"})
+		var called bool
+		d := b.Build(func(values FormValues) {
+			called = true
+		})
+		d.Show()
+		d.Dismiss()
+		assert.False(t, called)
+	})
+	t.Run("should validate entries", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		b := NewFormBuilder("Title", w)
+		b.AddString(FormField[string]{Key: "name", Label: "Name", Validators: []func(string) error{notEmpty}})
+		b.AddInt(FormField[int]{Key: "age", Label: "Age", Initial: 1})
+		b.Show(nil)
+		name := b.fields[0].item.Widget.(*widget.Entry)
+		age := b.fields[1].item.Widget.(*widget.Entry)
+		assert.Error(t, name.Validate())
+		name.SetText("Alice")
+		assert.NoError(t, name.Validate())
+		age.SetText("x")
+		assert.Error(t, age.Validate())
+		age.SetText("3")
+		assert.NoError(t, age.Validate())
+	})
+	t.Run("should validate other inputs", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		b := NewFormBuilder("Title", w)
+		b.AddChoice(FormField[string]{Key: "color", Label: "Color", Validators: []func(string) error{notEmpty}}, []string{"Red"})
+		b.Show(nil)
+		input := b.fields[0].item.Widget.(*validatedInput)
+		var changed []error
+		input.SetOnValidationChanged(func(err error) {
+			changed = append(changed, err)
+		})
+		assert.Error(t, input.Validate())
+		input.content.(*fyne.Container).Objects[0].(*kxwidget.FilterChipSelect).SetSelected("Red")
+		assert.NoError(t, input.Validate())
+		assert.Equal(t, []error{nil}, changed)
+	})
+}
+
+func TestFormValuesDecode(t *testing.T) {
+	type settings struct {
+		Name    string    `form:"name"`
+		Retries int64     `form:"retries"`
+		Ratio   float32   `form:"ratio"`
+		Enabled bool      `form:"enabled"`
+		Start   time.Time `form:"start"`
+		Other   string
+		Ignored string `form:"-"`
+	}
+	start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	t.Run("should decode values into struct", func(t *testing.T) {
+		values := FormValues{
+			"name":    "Alice",
+			"retries": 3,
+			"ratio":   0.5,
+			"enabled": true,
+			"start":   start,
+			"Other":   "x",
+			"-":       "x",
+		}
+		var got settings
+		err := values.Decode(&got)
+		if assert.NoError(t, err) {
+			assert.Equal(t, settings{Name: "Alice", Retries: 3, Ratio: 0.5, Enabled: true, Start: start}, got)
+		}
+	})
+	t.Run("should keep fields without values", func(t *testing.T) {
+		got := settings{Name: "Alice"}
+		err := FormValues{"retries": 3}.Decode(&got)
+		if assert.NoError(t, err) {
+			assert.Equal(t, settings{Name: "Alice", Retries: 3}, got)
+		}
+	})
+	t.Run("should return error when value can not be stored in field", func(t *testing.T) {
+		var got settings
+		err := FormValues{"name": 3}.Decode(&got)
+		assert.Error(t, err)
+	})
+	t.Run("should return error when float value would be truncated into int field", func(t *testing.T) {
+		var got settings
+		err := FormValues{"retries": 2.9}.Decode(&got)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), got.Retries)
+	})
+	t.Run("should decode int value into smaller int field when it fits", func(t *testing.T) {
+		var got struct {
+			Level int8 `form:"level"`
+		}
+		err := FormValues{"level": 100}.Decode(&got)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int8(100), got.Level)
+		}
+	})
+	t.Run("should return error when int value does not fit into field", func(t *testing.T) {
+		var got struct {
+			Level int8 `form:"level"`
+		}
+		err := FormValues{"level": 300}.Decode(&got)
+		assert.Error(t, err)
+	})
+	t.Run("should return error when int value would change sign", func(t *testing.T) {
+		var got struct {
+			Count uint `form:"count"`
+		}
+		err := FormValues{"count": -1}.Decode(&got)
+		assert.Error(t, err)
+	})
+	t.Run("should return error when target is not a struct pointer", func(t *testing.T) {
+		var got settings
+		err := FormValues{}.Decode(got)
+		assert.Error(t, err)
+	})
+}
@@ -2,25 +2,188 @@
 package dialog
 
 import (
+	"sync"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/ErikKalkoken/fyne-kx/internal/stack"
 )
 
 // AddDialogKeyHandler adds a key handler to a dialog.
 // It enables the user to close the dialog by pressing the escape key.
 //
 // Note that previously defined key events will be deactivated while the dialog is open.
+// When several dialogs of the same window have key handlers,
+// key events go to the dialog opened last
+// and the previous key events are restored once all those dialogs are closed.
 func AddDialogKeyHandler(d dialog.Dialog, w fyne.Window) {
-	originalEvent := w.Canvas().OnTypedKey() // == nil when not set
-	w.Canvas().SetOnTypedKey(func(ke *fyne.KeyEvent) {
-		if d == nil {
+	setDialogKeyHandler(d, w, func(ke *fyne.KeyEvent) {
+		if ke.Name == fyne.KeyEscape {
+			d.Hide()
+		}
+	})
+}
+
+// AddDialogKeyHandlerWithBindings adds a key handler to a dialog, which supports these keys:
+//   - Escape closes the dialog
+//   - Enter and Return confirm the dialog, when it is a confirm, custom confirm or form dialog,
+//     and continue to the next page of a [Wizard]
+//
+// Focus traversal with Tab and Shift+Tab is left to the canvas.
+//
+// Keys typed while a widget has the focus go to that widget and not to the key handler.
+// Entries on the pages of a [Wizard] therefore continue to the next page with Enter and Return on their own.
+// For other dialogs use [SubmitOnEnter] to let entries confirm the dialog.
+//
+// Additional key bindings can be defined with bindings. They take precedence over the keys above.
+//
+// Note that previously defined key events will be deactivated while the dialog is open.
+func AddDialogKeyHandlerWithBindings(d dialog.Dialog, w fyne.Window, bindings map[fyne.KeyName]func()) {
+	confirm := confirmFunc(d)
+	if wiz, ok := d.(*Wizard); ok {
+		for _, p := range wiz.pages {
+			SubmitOnEnter(wiz, p.Content)
+		}
+	}
+	setDialogKeyHandler(d, w, func(ke *fyne.KeyEvent) {
+		if f, ok := bindings[ke.Name]; ok {
+			f()
 			return
 		}
-		if ke.Name == fyne.KeyEscape {
+		switch ke.Name {
+		case fyne.KeyEscape:
 			d.Hide()
+		case fyne.KeyEnter, fyne.KeyReturn:
+			if confirm != nil {
+				confirm()
+			}
+		}
+	})
+}
+
+// SubmitOnEnter lets the entries in objects confirm dialog d,
+// when the user presses Enter or Return while typing in one of them.
+// It has the same effect as pressing Enter in a dialog with a key handler from [AddDialogKeyHandlerWithBindings].
+//
+// Objects can be entries and containers or forms with entries, e.g. the widgets of the items of a form dialog.
+// Existing OnSubmitted callbacks of the entries are called first.
+// Note that multi-line entries submit with Shift+Enter, so that Enter still adds a new line.
+// Nothing happens when d can not be confirmed.
+func SubmitOnEnter(d dialog.Dialog, objects ...fyne.CanvasObject) {
+	confirm := confirmFunc(d)
+	if confirm == nil {
+		return
+	}
+	for _, o := range objects {
+		for _, e := range findEntries(o) {
+			submitted := e.OnSubmitted
+			e.OnSubmitted = func(s string) {
+				if submitted != nil {
+					submitted(s)
+				}
+				confirm()
+			}
+		}
+	}
+}
+
+// findEntries returns the entries in the tree of o.
+// It looks into containers, scroll containers and forms, but not into other widgets.
+func findEntries(o fyne.CanvasObject) []*widget.Entry {
+	switch x := o.(type) {
+	case *widget.Entry:
+		return []*widget.Entry{x}
+	case *fyne.Container:
+		var entries []*widget.Entry
+		for _, c := range x.Objects {
+			entries = append(entries, findEntries(c)...)
+		}
+		return entries
+	case *container.Scroll:
+		return findEntries(x.Content)
+	case *widget.Form:
+		var entries []*widget.Entry
+		for _, it := range x.Items {
+			entries = append(entries, findEntries(it.Widget)...)
 		}
+		return entries
+	}
+	return nil
+}
+
+// confirmFunc returns the function for confirming a dialog or nil if the dialog can not be confirmed.
+func confirmFunc(d dialog.Dialog) func() {
+	switch x := d.(type) {
+	case *dialog.ConfirmDialog:
+		return x.Confirm
+	case *dialog.FormDialog:
+		return x.Submit
+	case *Wizard:
+		return x.Next
+	}
+	return nil
+}
+
+// keyHandlerEntry is the key handler of an open dialog.
+type keyHandlerEntry struct {
+	d       dialog.Dialog
+	handler func(ke *fyne.KeyEvent)
+}
+
+// windowKeyHandlers keeps the key handlers of all open dialogs of a window.
+type windowKeyHandlers struct {
+	original func(ke *fyne.KeyEvent) // == nil when not set
+	entries  *stack.Stack[*keyHandlerEntry]
+}
+
+// keyHandlers keeps track of the key handlers of open dialogs for each window.
+var keyHandlers = struct {
+	mu      sync.Mutex
+	windows map[fyne.Window]*windowKeyHandlers
+}{
+	windows: make(map[fyne.Window]*windowKeyHandlers),
+}
+
+// setDialogKeyHandler installs handler for dialog d in window w.
+//
+// Key events are routed to the handler of the top-most open dialog in w.
+// The original key handler of the window is restored once all its dialogs are closed,
+// regardless of the order in which they are closed.
+// Existing OnClosed callbacks of d are preserved.
+func setDialogKeyHandler(d dialog.Dialog, w fyne.Window, handler func(ke *fyne.KeyEvent)) {
+	if d == nil {
+		return
+	}
+	e := &keyHandlerEntry{d: d, handler: handler}
+	keyHandlers.mu.Lock()
+	wh, ok := keyHandlers.windows[w]
+	if !ok {
+		wh = &windowKeyHandlers{
+			original: w.Canvas().OnTypedKey(),
+			entries:  stack.New[*keyHandlerEntry](),
+		}
+		keyHandlers.windows[w] = wh
+	}
+	wh.entries.Push(e)
+	keyHandlers.mu.Unlock()
+	w.Canvas().SetOnTypedKey(func(ke *fyne.KeyEvent) {
+		top, err := wh.entries.Peek()
+		if err != nil {
+			return
+		}
+		top.handler(ke)
 	})
-	d.SetOnClosed(func() {
-		w.Canvas().SetOnTypedKey(originalEvent)
+	d.SetOnClosed(func() { // chains with existing OnClosed callbacks
+		keyHandlers.mu.Lock()
+		defer keyHandlers.mu.Unlock()
+		if !wh.entries.Remove(e) || wh.entries.Size() > 0 {
+			return
+		}
+		if keyHandlers.windows[w] == wh {
+			delete(keyHandlers.windows, w)
+		}
+		w.Canvas().SetOnTypedKey(wh.original)
 	})
 }
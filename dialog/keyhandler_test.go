@@ -0,0 +1,233 @@
+package dialog_test
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+
+	kxdialog "github.com/ErikKalkoken/fyne-kx/dialog"
+)
+
+func typeKey(w fyne.Window, name fyne.KeyName) {
+	w.Canvas().OnTypedKey()(&fyne.KeyEvent{Name: name})
+}
+
+func TestAddDialogKeyHandler(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should close dialog with escape key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed, called bool
+		d := dialog.NewConfirm("Title", "Message", func(b bool) {
+			called = true
+			confirmed = b
+		}, w)
+		kxdialog.AddDialogKeyHandler(d, w)
+		d.Show()
+		typeKey(w, fyne.KeyEscape)
+		assert.True(t, called)
+		assert.False(t, confirmed)
+	})
+	t.Run("should restore original key handler when closed", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var pressed fyne.KeyName
+		w.Canvas().SetOnTypedKey(func(ke *fyne.KeyEvent) {
+			pressed = ke.Name
+		})
+		d := dialog.NewInformation("Title", "Message", w)
+		kxdialog.AddDialogKeyHandler(d, w)
+		d.Show()
+		typeKey(w, fyne.KeyEscape)
+		typeKey(w, fyne.KeyA)
+		assert.Equal(t, fyne.KeyA, pressed)
+	})
+	t.Run("should route keys to the dialog opened last", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var closed1, closed2 bool
+		d1 := dialog.NewConfirm("Title", "Message", func(bool) {
+			closed1 = true
+		}, w)
+		kxdialog.AddDialogKeyHandler(d1, w)
+		d1.Show()
+		d2 := dialog.NewConfirm("Title", "Message", func(bool) {
+			closed2 = true
+		}, w)
+		kxdialog.AddDialogKeyHandler(d2, w)
+		d2.Show()
+		typeKey(w, fyne.KeyEscape)
+		assert.False(t, closed1)
+		assert.True(t, closed2)
+		typeKey(w, fyne.KeyEscape)
+		assert.True(t, closed1)
+	})
+	t.Run("should restore original key handler when stacked dialogs are closed out of order", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var pressed fyne.KeyName
+		w.Canvas().SetOnTypedKey(func(ke *fyne.KeyEvent) {
+			pressed = ke.Name
+		})
+		d1 := dialog.NewInformation("Title", "Message", w)
+		kxdialog.AddDialogKeyHandler(d1, w)
+		d1.Show()
+		var closed2 bool
+		d2 := dialog.NewConfirm("Title", "Message", func(bool) {
+			closed2 = true
+		}, w)
+		kxdialog.AddDialogKeyHandler(d2, w)
+		d2.Show()
+		d1.Hide()
+		typeKey(w, fyne.KeyA)
+		assert.Equal(t, fyne.KeyName(""), pressed)
+		typeKey(w, fyne.KeyEscape)
+		assert.True(t, closed2)
+		typeKey(w, fyne.KeyA)
+		assert.Equal(t, fyne.KeyA, pressed)
+	})
+	t.Run("should keep existing OnClosed callbacks", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var before, after bool
+		d := dialog.NewInformation("Title", "Message", w)
+		d.SetOnClosed(func() {
+			before = true
+		})
+		kxdialog.AddDialogKeyHandler(d, w)
+		d.SetOnClosed(func() {
+			after = true
+		})
+		d.Show()
+		typeKey(w, fyne.KeyEscape)
+		assert.True(t, before)
+		assert.True(t, after)
+	})
+}
+
+func TestAddDialogKeyHandlerWithBindings(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should confirm confirm dialog with return key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed bool
+		d := dialog.NewConfirm("Title", "Message", func(b bool) {
+			confirmed = b
+		}, w)
+		kxdialog.AddDialogKeyHandlerWithBindings(d, w, nil)
+		d.Show()
+		typeKey(w, fyne.KeyReturn)
+		assert.True(t, confirmed)
+	})
+	t.Run("should confirm custom confirm dialog with enter key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed bool
+		d := dialog.NewCustomConfirm("Title", "OK", "Cancel", widget.NewLabel("Content"), func(b bool) {
+			confirmed = b
+		}, w)
+		kxdialog.AddDialogKeyHandlerWithBindings(d, w, nil)
+		d.Show()
+		typeKey(w, fyne.KeyEnter)
+		assert.True(t, confirmed)
+	})
+	t.Run("should submit form dialog with return key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed bool
+		d := dialog.NewForm("Title", "OK", "Cancel", []*widget.FormItem{
+			widget.NewFormItem("Name", widget.NewEntry()),
+		}, func(b bool) {
+			confirmed = b
+		}, w)
+		kxdialog.AddDialogKeyHandlerWithBindings(d, w, nil)
+		d.Show()
+		typeKey(w, fyne.KeyReturn)
+		assert.True(t, confirmed)
+	})
+	t.Run("should close dialog with escape key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed, called bool
+		d := dialog.NewConfirm("Title", "Message", func(b bool) {
+			called = true
+			confirmed = b
+		}, w)
+		kxdialog.AddDialogKeyHandlerWithBindings(d, w, nil)
+		d.Show()
+		typeKey(w, fyne.KeyEscape)
+		assert.True(t, called)
+		assert.False(t, confirmed)
+	})
+	t.Run("should run custom key bindings", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var pressed bool
+		d := dialog.NewInformation("Title", "Message", w)
+		kxdialog.AddDialogKeyHandlerWithBindings(d, w, map[fyne.KeyName]func(){
+			fyne.KeyF1: func() {
+				pressed = true
+			},
+		})
+		d.Show()
+		typeKey(w, fyne.KeyF1)
+		assert.True(t, pressed)
+	})
+}
+
+func TestSubmitOnEnter(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should submit form dialog from focused entry", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed bool
+		name := widget.NewEntry()
+		items := []*widget.FormItem{widget.NewFormItem("Name", name)}
+		d := dialog.NewForm("Title", "OK", "Cancel", items, func(b bool) {
+			confirmed = b
+		}, w)
+		kxdialog.AddDialogKeyHandlerWithBindings(d, w, nil)
+		kxdialog.SubmitOnEnter(d, name)
+		d.Show()
+		w.Canvas().Focus(name)
+		test.Type(name, "Alice")
+		name.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+		assert.True(t, confirmed)
+	})
+	t.Run("should find entries in containers", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var confirmed bool
+		entry := widget.NewEntry()
+		c := container.NewVBox(widget.NewLabel("Name"), container.NewBorder(nil, nil, nil, nil, entry))
+		d := dialog.NewCustomConfirm("Title", "OK", "Cancel", c, func(b bool) {
+			confirmed = b
+		}, w)
+		kxdialog.SubmitOnEnter(d, c)
+		d.Show()
+		entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnter})
+		assert.True(t, confirmed)
+	})
+	t.Run("should keep existing OnSubmitted callbacks", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var submitted, confirmed bool
+		entry := widget.NewEntry()
+		entry.OnSubmitted = func(string) {
+			submitted = true
+		}
+		d := dialog.NewCustomConfirm("Title", "OK", "Cancel", entry, func(b bool) {
+			confirmed = b
+		}, w)
+		kxdialog.SubmitOnEnter(d, entry)
+		d.Show()
+		entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+		assert.True(t, submitted)
+		assert.True(t, confirmed)
+	})
+}
@@ -0,0 +1,129 @@
+package dialog
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/ErikKalkoken/fyne-kx/internal/searchdialog"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+)
+
+// SearchDialog is a dialog for searching and selecting items from a potentially long list of items.
+// The user can narrow down the list by typing a search text with at least two characters.
+// Matching items are ranked by how well they match and the matched characters are highlighted,
+// unless the items are rendered with custom objects.
+//
+// By default the user selects one item by tapping it.
+// In multi-select mode the user can toggle several items and confirms the selection with a button.
+//
+// The fields can be set after the dialog was created and are applied when the dialog is shown.
+type SearchDialog[T comparable] struct {
+	// Label of the button for clearing the selection.
+	// The button is shown only when items are selected.
+	ClearLabel string
+
+	// Optional function for creating the object which renders an item in the list.
+	// Must be used together with UpdateItem.
+	CreateItem func() fyne.CanvasObject
+
+	// Optional function for updating the object created by CreateItem with an item.
+	UpdateItem func(item T, co fyne.CanvasObject)
+
+	// Optional function, which returns the text of an item.
+	// The text is used for matching items and for rendering items when CreateItem is not set.
+	// By default the text is created with [fmt.Sprint].
+	ItemText func(item T) string
+
+	// Matcher for matching the text of items against the search text.
+	// The default is a [FuzzyMatcher].
+	Matcher Matcher
+
+	// Whether the user can select several items.
+	Multiple bool
+
+	// The currently selected items, which are marked with a check icon.
+	Selected []T
+
+	d          *searchdialog.Dialog[T]
+	items      []T
+	onSelected func(selected []T)
+	parent     fyne.Window
+	title      string
+}
+
+// NewSearchDialog returns a new [SearchDialog] for items.
+//
+// The callback onSelected is called with the selected items when the user makes a selection.
+// In single select mode it receives one item or no items when the user cleared the selection.
+// It is not called when the user cancels the dialog.
+func NewSearchDialog[T comparable](title string, items []T, onSelected func(selected []T), parent fyne.Window) *SearchDialog[T] {
+	d := &SearchDialog[T]{
+		ClearLabel: "Clear",
+		Matcher:    FuzzyMatcher{},
+		items:      items,
+		onSelected: onSelected,
+		parent:     parent,
+		title:      title,
+	}
+	return d
+}
+
+// ShowSearchDialog creates and shows a [SearchDialog] for selecting one item.
+func ShowSearchDialog[T comparable](title string, items []T, onSelected func(selected []T), parent fyne.Window) {
+	NewSearchDialog(title, items, onSelected, parent).Show()
+}
+
+// Show shows the dialog.
+func (d *SearchDialog[T]) Show() {
+	var match func(text, search string) (int, []int, bool)
+	if d.Matcher != nil {
+		match = d.Matcher.Match
+	}
+	d.d = searchdialog.New(searchdialog.Config[T]{
+		ClearLabel: d.ClearLabel,
+		CreateItem: d.CreateItem,
+		IconButton: func(icon fyne.Resource, tapped func()) fyne.CanvasObject {
+			return kxwidget.NewIconButton(icon, tapped)
+		},
+		Items:      d.items,
+		ItemText:   d.ItemText,
+		Match:      match,
+		Multiple:   d.Multiple,
+		OnSelected: d.onSelected,
+		Selected:   d.Selected,
+		Title:      d.title,
+		UpdateItem: d.UpdateItem,
+		Window:     d.parent,
+	})
+	d.d.Show()
+}
+
+// Hide hides the dialog.
+func (d *SearchDialog[T]) Hide() {
+	if d.d != nil {
+		d.d.Hide()
+	}
+}
+
+// Matcher matches the text of items against a search text.
+type Matcher interface {
+	// Match reports whether text matches search.
+	// It also returns a score for ranking matches, where a higher score is a better match,
+	// and the positions of the matched runes in text for highlighting them.
+	Match(text, search string) (score int, positions []int, ok bool)
+}
+
+// FuzzyMatcher matches texts which contain all characters of the search text in the same order,
+// ignoring case and diacritics. For example "jurg" matches "Jürgen".
+// Matches at the start of the text or a word and consecutive matches are ranked higher.
+type FuzzyMatcher struct{}
+
+func (FuzzyMatcher) Match(text, search string) (int, []int, bool) {
+	return searchdialog.Fuzzy(text, search)
+}
+
+// SubstringMatcher matches texts which contain the search text, ignoring case and diacritics.
+// Matches at the start of the text or a word are ranked higher.
+type SubstringMatcher struct{}
+
+func (SubstringMatcher) Match(text, search string) (int, []int, bool) {
+	return searchdialog.Substring(text, search)
+}
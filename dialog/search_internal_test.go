@@ -0,0 +1,126 @@
+package dialog
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchDialog(t *testing.T) {
+	test.NewTempApp(t)
+	items := []string{"Alpha", "Bravo", "Charlie"}
+	t.Run("should return selected item", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var selected []string
+		d := NewSearchDialog("Title", items, func(s []string) {
+			selected = s
+		}, w)
+		d.Show()
+		d.d.List.Select(1)
+		assert.Equal(t, []string{"Bravo"}, selected)
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+	t.Run("should filter items by search text", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d := NewSearchDialog("Title", items, nil, w)
+		d.Show()
+		d.d.Entry.SetText("v")
+		assert.Equal(t, 3, d.d.List.Length(), "should not filter below minimum length")
+		d.d.Entry.SetText("ha")
+		assert.Equal(t, 2, d.d.List.Length())
+		d.d.Entry.SetText("")
+		assert.Equal(t, 3, d.d.List.Length())
+	})
+	t.Run("can use custom matcher", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d := NewSearchDialog("Title", items, nil, w)
+		d.Matcher = SubstringMatcher{}
+		d.Show()
+		d.d.Entry.SetText("ar")
+		assert.Equal(t, 1, d.d.List.Length())
+		d.d.Entry.SetText("ae")
+		assert.Equal(t, 0, d.d.List.Length())
+	})
+	t.Run("should rank best matches first", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var selected []string
+		d := NewSearchDialog("Title", []string{"Hans Jürgen", "Anja", "Jürgen"}, func(s []string) {
+			selected = s
+		}, w)
+		d.Show()
+		d.d.Entry.SetText("jurg")
+		assert.Equal(t, 2, d.d.List.Length())
+		d.d.List.Select(0)
+		assert.Equal(t, []string{"Jürgen"}, selected)
+	})
+	t.Run("can select multiple items", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var selected []string
+		d := NewSearchDialog("Title", items, func(s []string) {
+			selected = s
+		}, w)
+		d.Multiple = true
+		d.Selected = []string{"Bravo"}
+		d.Show()
+		d.d.List.Select(2)
+		d.d.List.Select(0)
+		d.d.List.Select(1)
+		assert.NotNil(t, w.Canvas().Overlays().Top())
+		test.Tap(d.d.Confirm)
+		assert.Equal(t, []string{"Alpha", "Charlie"}, selected)
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+	t.Run("can clear selection", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var selected []string
+		d := NewSearchDialog("Title", items, func(s []string) {
+			selected = s
+		}, w)
+		d.Selected = []string{"Bravo"}
+		d.Show()
+		assert.True(t, d.d.Clear.Visible())
+		test.Tap(d.d.Clear)
+		assert.Equal(t, []string{}, selected)
+	})
+	t.Run("can render items with custom objects", func(t *testing.T) {
+		type user struct {
+			id   int
+			name string
+		}
+		w := test.NewWindow(nil)
+		defer w.Close()
+		w.Resize(fyne.NewSize(800, 600))
+		users := []user{{1, "Alice"}, {2, "Bob"}}
+		var selected []user
+		d := NewSearchDialog("Title", users, func(s []user) {
+			selected = s
+		}, w)
+		d.ItemText = func(u user) string {
+			return u.name
+		}
+		rendered := make(map[int]bool)
+		d.CreateItem = func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		}
+		d.UpdateItem = func(u user, co fyne.CanvasObject) {
+			rendered[u.id] = true
+			co.(*widget.Label).SetText(strings.ToUpper(u.name))
+		}
+		d.Show()
+		w.Canvas().Capture()
+		assert.Equal(t, map[int]bool{1: true, 2: true}, rendered)
+		d.d.Entry.SetText("bo")
+		d.d.List.Select(0)
+		assert.Equal(t, []user{{2, "Bob"}}, selected)
+	})
+}
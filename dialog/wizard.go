@@ -0,0 +1,252 @@
+package dialog
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// WizardPage is a page of a [Wizard].
+type WizardPage struct {
+	// Title of the page, which is shown in the step indicator.
+	Title string
+
+	// Content of the page.
+	Content fyne.CanvasObject
+
+	// Optional function, which is called when the user wants to continue to the next page.
+	// Returning an error keeps the wizard on this page and shows the error to the user.
+	Validate func() error
+
+	// Optional function, which reports whether the page should be skipped.
+	// It is called whenever the wizard navigates, so it can depend on the input of previous pages.
+	Skip func() bool
+}
+
+// Wizard is a dialog which guides the user through a sequence of pages.
+// The user navigates between the pages with Back and Next buttons
+// and completes the wizard with the Finish button on the last page.
+//
+// A wizard implements the [dialog.Dialog] interface,
+// so it can be closed with the escape key by adding a key handler with [AddDialogKeyHandler].
+// With [AddDialogKeyHandlerWithBindings] the enter key also continues to the next page.
+type Wizard struct {
+	// Optional callback when the wizard was closed without finishing it.
+	OnCancel func()
+
+	// Optional callback when the user finished the wizard.
+	OnFinish func()
+
+	// Whether to show a step indicator above the pages, e.g. "Step 2 of 3: Settings".
+	ShowSteps bool
+
+	back     *widget.Button
+	cancel   *widget.Button
+	current  int
+	d        *dialog.CustomDialog
+	errLabel *widget.Label
+	finished bool
+	next     *widget.Button
+	pages    []*WizardPage
+	steps    *widget.Label
+}
+
+var _ dialog.Dialog = (*Wizard)(nil)
+
+// NewWizard returns a new [Wizard] with pages.
+// A wizard without pages is never shown.
+func NewWizard(title string, pages []*WizardPage, parent fyne.Window) *Wizard {
+	w := &Wizard{current: -1, pages: pages}
+	w.steps = widget.NewLabel("")
+	w.steps.TextStyle.Bold = true
+	w.errLabel = widget.NewLabel("")
+	w.errLabel.Importance = widget.DangerImportance
+	w.errLabel.Wrapping = fyne.TextWrapWord
+	w.errLabel.Hide()
+	contents := container.NewStack()
+	for _, p := range pages {
+		contents.Add(p.Content)
+	}
+	w.cancel = widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), w.Hide)
+	w.back = widget.NewButtonWithIcon("Back", theme.NavigateBackIcon(), w.Back)
+	w.next = widget.NewButtonWithIcon("Next", theme.NavigateNextIcon(), w.Next)
+	w.next.IconPlacement = widget.ButtonIconTrailingText
+	w.d = dialog.NewCustomWithoutButtons(title, container.NewBorder(
+		w.steps,
+		w.errLabel,
+		nil,
+		nil,
+		contents,
+	), parent)
+	w.d.SetButtons([]fyne.CanvasObject{w.cancel, w.back, w.next})
+	w.d.SetOnClosed(func() {
+		if !w.finished && w.OnCancel != nil {
+			w.OnCancel()
+		}
+	})
+	return w
+}
+
+// Current returns the index of the current page or -1 when no page is shown.
+func (w *Wizard) Current() int {
+	return w.current
+}
+
+// Show shows the wizard starting with the first page, which is not skipped.
+// Nothing happens when there is no such page, e.g. because all pages are skipped.
+func (w *Wizard) Show() {
+	w.current = w.nextPage(-1)
+	if w.current < 0 {
+		return
+	}
+	w.finished = false
+	w.updatePage()
+	w.d.Show()
+}
+
+// Next validates the current page and continues to the next page
+// or finishes the wizard when the current page is the last page.
+func (w *Wizard) Next() {
+	if w.current < 0 {
+		return
+	}
+	if p := w.pages[w.current]; p.Validate != nil {
+		if err := p.Validate(); err != nil {
+			w.errLabel.SetText(err.Error())
+			w.errLabel.Show()
+			return
+		}
+	}
+	next := w.nextPage(w.current)
+	if next < 0 {
+		w.finished = true
+		w.d.Hide()
+		if w.OnFinish != nil {
+			w.OnFinish()
+		}
+		return
+	}
+	w.current = next
+	w.updatePage()
+}
+
+// Back returns to the previous page.
+func (w *Wizard) Back() {
+	if w.current < 0 {
+		return
+	}
+	prev := w.previousPage(w.current)
+	if prev < 0 {
+		return
+	}
+	w.current = prev
+	w.updatePage()
+}
+
+// nextPage returns the index of the next page after i, which is not skipped, or -1 if there is none.
+func (w *Wizard) nextPage(i int) int {
+	for j := i + 1; j < len(w.pages); j++ {
+		if !w.isSkipped(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+// previousPage returns the index of the previous page before i, which is not skipped, or -1 if there is none.
+func (w *Wizard) previousPage(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if !w.isSkipped(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+func (w *Wizard) isSkipped(i int) bool {
+	p := w.pages[i]
+	return p.Skip != nil && p.Skip()
+}
+
+func (w *Wizard) updatePage() {
+	for i, p := range w.pages {
+		if i == w.current {
+			p.Content.Show()
+		} else {
+			p.Content.Hide()
+		}
+	}
+	w.errLabel.Hide()
+	if w.previousPage(w.current) < 0 {
+		w.back.Disable()
+	} else {
+		w.back.Enable()
+	}
+	if w.nextPage(w.current) < 0 {
+		w.next.SetText("Finish")
+		w.next.SetIcon(theme.ConfirmIcon())
+		w.next.Importance = widget.HighImportance
+	} else {
+		w.next.SetText("Next")
+		w.next.SetIcon(theme.NavigateNextIcon())
+		w.next.Importance = widget.MediumImportance
+	}
+	w.next.Refresh()
+	if !w.ShowSteps {
+		w.steps.Hide()
+		return
+	}
+	var step, total int
+	for i := range w.pages {
+		if w.isSkipped(i) {
+			continue
+		}
+		total++
+		if i <= w.current {
+			step++
+		}
+	}
+	text := fmt.Sprintf("Step %d of %d", step, total)
+	if t := w.pages[w.current].Title; t != "" {
+		text += ": " + t
+	}
+	w.steps.SetText(text)
+	w.steps.Show()
+}
+
+// Hide closes the wizard without finishing it.
+func (w *Wizard) Hide() {
+	w.d.Hide()
+}
+
+// Dismiss closes the wizard without finishing it.
+func (w *Wizard) Dismiss() {
+	w.d.Hide()
+}
+
+// SetDismissText sets the label of the Cancel button.
+func (w *Wizard) SetDismissText(label string) {
+	w.cancel.SetText(label)
+}
+
+// SetOnClosed sets a callback, which is called when the wizard is closed,
+// regardless of whether it was finished or canceled.
+func (w *Wizard) SetOnClosed(closed func()) {
+	w.d.SetOnClosed(closed)
+}
+
+func (w *Wizard) Refresh() {
+	w.d.Refresh()
+}
+
+func (w *Wizard) Resize(size fyne.Size) {
+	w.d.Resize(size)
+}
+
+func (w *Wizard) MinSize() fyne.Size {
+	return w.d.MinSize()
+}
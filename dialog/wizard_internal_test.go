@@ -0,0 +1,185 @@
+package dialog
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeWizardPages(n int) []*WizardPage {
+	pages := make([]*WizardPage, n)
+	for i := range pages {
+		pages[i] = &WizardPage{Content: widget.NewLabel("Content")}
+	}
+	return pages
+}
+
+func TestWizard(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should navigate through pages and finish", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var finished, canceled bool
+		pages := makeWizardPages(3)
+		wiz := NewWizard("Title", pages, w)
+		wiz.OnFinish = func() {
+			finished = true
+		}
+		wiz.OnCancel = func() {
+			canceled = true
+		}
+		wiz.Show()
+		assert.Equal(t, 0, wiz.Current())
+		assert.True(t, wiz.back.Disabled())
+		assert.True(t, pages[0].Content.Visible())
+		assert.False(t, pages[1].Content.Visible())
+		wiz.Next()
+		assert.Equal(t, 1, wiz.Current())
+		assert.False(t, wiz.back.Disabled())
+		assert.True(t, pages[1].Content.Visible())
+		wiz.Back()
+		assert.Equal(t, 0, wiz.Current())
+		wiz.Next()
+		wiz.Next()
+		assert.Equal(t, "Finish", wiz.next.Text)
+		wiz.Next()
+		assert.True(t, finished)
+		assert.False(t, canceled)
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+	t.Run("should block next when validation fails", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		pages := makeWizardPages(2)
+		valid := false
+		pages[0].Validate = func() error {
+			if !valid {
+				return errors.New("name missing")
+			}
+			return nil
+		}
+		wiz := NewWizard("Title", pages, w)
+		wiz.Show()
+		wiz.Next()
+		assert.Equal(t, 0, wiz.Current())
+		assert.True(t, wiz.errLabel.Visible())
+		assert.Equal(t, "name missing", wiz.errLabel.Text)
+		valid = true
+		wiz.Next()
+		assert.Equal(t, 1, wiz.Current())
+		assert.False(t, wiz.errLabel.Visible())
+	})
+	t.Run("should skip pages", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		pages := makeWizardPages(3)
+		skip := true
+		pages[1].Skip = func() bool {
+			return skip
+		}
+		pages[2].Title = "Summary"
+		wiz := NewWizard("Title", pages, w)
+		wiz.ShowSteps = true
+		wiz.Show()
+		assert.Equal(t, "Step 1 of 2", wiz.steps.Text)
+		wiz.Next()
+		assert.Equal(t, 2, wiz.Current())
+		assert.Equal(t, "Step 2 of 2: Summary", wiz.steps.Text)
+		skip = false
+		wiz.Back()
+		assert.Equal(t, 1, wiz.Current())
+	})
+	t.Run("should skip first page", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		pages := makeWizardPages(2)
+		pages[0].Skip = func() bool {
+			return true
+		}
+		wiz := NewWizard("Title", pages, w)
+		wiz.Show()
+		assert.Equal(t, 1, wiz.Current())
+		assert.False(t, pages[0].Content.Visible())
+		assert.True(t, pages[1].Content.Visible())
+	})
+	t.Run("should not show wizard when all pages are skipped", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		pages := makeWizardPages(2)
+		for _, p := range pages {
+			p.Skip = func() bool {
+				return true
+			}
+		}
+		wiz := NewWizard("Title", pages, w)
+		wiz.Show()
+		assert.Equal(t, -1, wiz.Current())
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		wiz.Next()
+		wiz.Back()
+		assert.Equal(t, -1, wiz.Current())
+	})
+	t.Run("should not show wizard without pages", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var finished bool
+		wiz := NewWizard("Title", nil, w)
+		wiz.OnFinish = func() {
+			finished = true
+		}
+		wiz.Show()
+		wiz.Next()
+		wiz.Back()
+		assert.Equal(t, -1, wiz.Current())
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		assert.False(t, finished)
+	})
+	t.Run("should hide step indicator by default", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		wiz := NewWizard("Title", makeWizardPages(2), w)
+		wiz.Show()
+		assert.False(t, wiz.steps.Visible())
+	})
+	t.Run("should cancel with escape key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		var canceled bool
+		wiz := NewWizard("Title", makeWizardPages(2), w)
+		wiz.OnCancel = func() {
+			canceled = true
+		}
+		AddDialogKeyHandler(wiz, w)
+		wiz.Show()
+		w.Canvas().OnTypedKey()(&fyne.KeyEvent{Name: fyne.KeyEscape})
+		assert.True(t, canceled)
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+	t.Run("should continue with enter key", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		wiz := NewWizard("Title", makeWizardPages(2), w)
+		AddDialogKeyHandlerWithBindings(wiz, w, nil)
+		wiz.Show()
+		w.Canvas().OnTypedKey()(&fyne.KeyEvent{Name: fyne.KeyReturn})
+		assert.Equal(t, 1, wiz.Current())
+	})
+	t.Run("should continue with enter key in focused entry", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		entry := widget.NewEntry()
+		pages := makeWizardPages(2)
+		pages[0].Content = container.NewVBox(widget.NewLabel("Name"), entry)
+		wiz := NewWizard("Title", pages, w)
+		AddDialogKeyHandlerWithBindings(wiz, w, nil)
+		wiz.Show()
+		w.Canvas().Focus(entry)
+		entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+		assert.Equal(t, 1, wiz.Current())
+	})
+}
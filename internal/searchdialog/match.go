@@ -0,0 +1,185 @@
+package searchdialog
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Scores for ranking matches.
+const (
+	scoreMatch       = 1   // for each matched rune
+	scoreConsecutive = 4   // for each matched rune following a matched rune
+	scoreWordStart   = 6   // for each matched rune at the start of a word
+	scorePrefix      = 100 // when the search text is a prefix of the text
+	penaltyGap       = 1   // for each unmatched rune between matched runes
+)
+
+// specialFolds maps letters without a Unicode decomposition to their base letters.
+var specialFolds = map[rune]rune{
+	'æ': 'a',
+	'đ': 'd',
+	'ł': 'l',
+	'ø': 'o',
+	'ß': 's',
+}
+
+// foldRune returns the lower case base letter of r, e.g. 'u' for 'Ü'.
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if r < utf8.RuneSelf {
+		return r
+	}
+	if x, ok := specialFolds[r]; ok {
+		return x
+	}
+	base, _ := utf8.DecodeRuneInString(norm.NFD.String(string(r)))
+	return base
+}
+
+// fold returns the folded runes of s. Each rune of s maps to exactly one folded rune.
+func fold(s string) []rune {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		runes = append(runes, foldRune(r))
+	}
+	return runes
+}
+
+// Fuzzy reports whether all runes of search appear in text in the same order,
+// ignoring case and diacritics, e.g. "jurg" matches "Jürgen".
+// It returns a score for ranking matches and the positions of the matched runes in text.
+// Matches at the start of the text or a word and consecutive matches score higher.
+func Fuzzy(text, search string) (int, []int, bool) {
+	t, s := fold(text), fold(search)
+	if len(s) == 0 {
+		return 0, nil, true
+	}
+	var best []int
+	bestScore := 0
+	try := func(positions []int) {
+		if positions == nil {
+			return
+		}
+		score := scorePositions(t, s, positions)
+		if best == nil || score > bestScore {
+			best = positions
+			bestScore = score
+		}
+	}
+	// Consecutive matches usually score best, so all substring matches are considered first.
+	for i := 0; i+len(s) <= len(t); i++ {
+		if equalRunes(t[i:i+len(s)], s) {
+			try(consecutive(i, len(s)))
+		}
+	}
+	try(greedy(t, s, 0))
+	// Starting the greedy search at each word start can find better alignments.
+	for i := 1; i < len(t); i++ {
+		if isWordStart(t, i) && t[i] == s[0] {
+			try(greedy(t, s, i))
+		}
+	}
+	if best == nil {
+		return 0, nil, false
+	}
+	return bestScore, best, true
+}
+
+// Substring reports whether search is contained in text, ignoring case and diacritics.
+// It returns a score for ranking matches and the positions of the matched runes in text.
+// Matches at the start of the text or a word score higher.
+func Substring(text, search string) (int, []int, bool) {
+	t, s := fold(text), fold(search)
+	if len(s) == 0 {
+		return 0, nil, true
+	}
+	var best []int
+	bestScore := 0
+	for i := 0; i+len(s) <= len(t); i++ {
+		if !equalRunes(t[i:i+len(s)], s) {
+			continue
+		}
+		positions := consecutive(i, len(s))
+		score := scorePositions(t, s, positions)
+		if best == nil || score > bestScore {
+			best = positions
+			bestScore = score
+		}
+	}
+	if best == nil {
+		return 0, nil, false
+	}
+	return bestScore, best, true
+}
+
+// greedy returns the positions of the first occurrence of all runes of s in t
+// starting at position start or nil if there is none.
+func greedy(t, s []rune, start int) []int {
+	positions := make([]int, 0, len(s))
+	j := 0
+	for i := start; i < len(t) && j < len(s); i++ {
+		if t[i] == s[j] {
+			positions = append(positions, i)
+			j++
+		}
+	}
+	if j < len(s) {
+		return nil
+	}
+	return positions
+}
+
+func consecutive(start, n int) []int {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return positions
+}
+
+func scorePositions(t, s []rune, positions []int) int {
+	score := 0
+	for k, p := range positions {
+		score += scoreMatch
+		if isWordStart(t, p) {
+			score += scoreWordStart
+		}
+		if k > 0 {
+			if gap := p - positions[k-1] - 1; gap == 0 {
+				score += scoreConsecutive
+			} else {
+				score -= gap * penaltyGap
+			}
+		}
+	}
+	if positions[0] == 0 && positions[len(positions)-1] == len(s)-1 {
+		score += scorePrefix
+	}
+	return score
+}
+
+// isWordStart reports whether the rune at position i of t starts a word.
+func isWordStart(t []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := t[i-1], t[i]
+	isAlnum := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return isAlnum(cur) && !isAlnum(prev)
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
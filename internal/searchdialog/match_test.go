@@ -0,0 +1,69 @@
+package searchdialog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/fyne-kx/internal/searchdialog"
+)
+
+func TestFuzzy(t *testing.T) {
+	cases := []struct {
+		name      string
+		text      string
+		search    string
+		ok        bool
+		positions []int
+	}{
+		{"empty search", "Alpha", "", true, nil},
+		{"prefix", "Alpha", "al", true, []int{0, 1}},
+		{"ignore case", "ALPHA", "lp", true, []int{1, 2}},
+		{"subsequence", "Charlie", "crl", true, []int{0, 3, 4}},
+		{"diacritics in text", "Jürgen", "jurg", true, []int{0, 1, 2, 3}},
+		{"diacritics in search", "Jurgen", "jürg", true, []int{0, 1, 2, 3}},
+		{"special letters", "Łódź", "lodz", true, []int{0, 1, 2, 3}},
+		{"prefer word start", "Hans Jürgen", "j", true, []int{5}},
+		{"prefer consecutive", "abcab", "ab", true, []int{0, 1}},
+		{"wrong order", "Alpha", "ahl", false, nil},
+		{"no match", "Alpha", "x", false, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, positions, ok := searchdialog.Fuzzy(tc.text, tc.search)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.positions, positions)
+		})
+	}
+}
+
+func TestFuzzyRanking(t *testing.T) {
+	score := func(text, search string) int {
+		s, _, ok := searchdialog.Fuzzy(text, search)
+		if !ok {
+			t.Fatalf("%s does not match %s", search, text)
+		}
+		return s
+	}
+	t.Run("should rank prefix match higher", func(t *testing.T) {
+		assert.Greater(t, score("Jürgen", "jurg"), score("Hans Jürgen", "jurg"))
+	})
+	t.Run("should rank word start higher than word middle", func(t *testing.T) {
+		assert.Greater(t, score("Hans Jürgen", "jurg"), score("Kojurgen", "jurg"))
+	})
+	t.Run("should rank consecutive match higher than scattered", func(t *testing.T) {
+		assert.Greater(t, score("xabc", "abc"), score("xaxbxc", "abc"))
+	})
+}
+
+func TestSubstring(t *testing.T) {
+	t.Run("should match substring ignoring case and diacritics", func(t *testing.T) {
+		_, positions, ok := searchdialog.Substring("Hans Jürgen", "JUR")
+		assert.True(t, ok)
+		assert.Equal(t, []int{5, 6, 7}, positions)
+	})
+	t.Run("should not match subsequence", func(t *testing.T) {
+		_, _, ok := searchdialog.Substring("Charlie", "crl")
+		assert.False(t, ok)
+	})
+}
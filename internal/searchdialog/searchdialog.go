@@ -0,0 +1,312 @@
+// Package searchdialog implements a dialog for searching and selecting items.
+//
+// It is shared by the public search dialog and the filter chip widgets
+// and must therefore not depend on any other package of this library.
+package searchdialog
+
+import (
+	"fmt"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// minSearchLength is the minimum length of a search text for filtering items.
+const minSearchLength = 2
+
+// Config is the configuration of a search dialog.
+type Config[T comparable] struct {
+	ClearLabel string
+	CreateItem func() fyne.CanvasObject
+	// IconButton creates the button for clearing the search text.
+	// It allows callers to use their own icon button widget, which this package can not import.
+	IconButton func(icon fyne.Resource, tapped func()) fyne.CanvasObject
+	Items      []T
+	ItemText   func(item T) string
+	Match      func(text, search string) (score int, positions []int, ok bool)
+	Multiple   bool
+	// NoEntries shows the "No entries" hint and disables the search even when there are items,
+	// e.g. when the only item is a selection which is no longer an option.
+	NoEntries  bool
+	OnSelected func(selected []T)
+	Selected   []T
+	Title      string
+	UpdateItem func(item T, co fyne.CanvasObject)
+	Window     fyne.Window
+}
+
+// Dialog is a dialog for searching and selecting items.
+type Dialog[T comparable] struct {
+	Clear   *widget.Button
+	Confirm *widget.Button
+	Entry   *widget.Entry
+	List    *widget.List
+
+	cfg        Config[T]
+	d          *dialog.CustomDialog
+	filtered   []T
+	highlights [][]int // positions of matched runes for each filtered item
+	selected   map[T]bool
+}
+
+// New returns a new search dialog for cfg.
+func New[T comparable](cfg Config[T]) *Dialog[T] {
+	if cfg.ClearLabel == "" {
+		cfg.ClearLabel = "Clear"
+	}
+	if cfg.ItemText == nil {
+		cfg.ItemText = func(item T) string {
+			return fmt.Sprint(item)
+		}
+	}
+	if cfg.Match == nil {
+		cfg.Match = Fuzzy
+	}
+	if cfg.IconButton == nil {
+		cfg.IconButton = func(icon fyne.Resource, tapped func()) fyne.CanvasObject {
+			b := widget.NewButtonWithIcon("", icon, tapped)
+			b.Importance = widget.LowImportance
+			return b
+		}
+	}
+	sd := &Dialog[T]{
+		cfg:      cfg,
+		filtered: cfg.Items,
+		selected: make(map[T]bool),
+	}
+	for _, v := range cfg.Selected {
+		sd.selected[v] = true
+	}
+	showIcons := cfg.Multiple || len(sd.selected) > 0
+	customItems := cfg.CreateItem != nil && cfg.UpdateItem != nil
+	sd.List = widget.NewList(
+		func() int {
+			return len(sd.filtered)
+		},
+		func() fyne.CanvasObject {
+			icon := widget.NewIcon(nil)
+			if !showIcons {
+				icon.Hide()
+			}
+			var item fyne.CanvasObject
+			if customItems {
+				item = cfg.CreateItem()
+			} else {
+				item = widget.NewRichText()
+			}
+			return container.NewBorder(nil, nil, icon, nil, item)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(sd.filtered) {
+				return
+			}
+			v := sd.filtered[id]
+			box := co.(*fyne.Container).Objects
+			if customItems {
+				cfg.UpdateItem(v, box[0])
+			} else {
+				var positions []int
+				if id < len(sd.highlights) {
+					positions = sd.highlights[id]
+				}
+				rt := box[0].(*widget.RichText)
+				rt.Segments = highlightSegments(cfg.ItemText(v), positions)
+				rt.Refresh()
+			}
+			if !showIcons {
+				return
+			}
+			icon := box[1].(*widget.Icon)
+			if sd.selected[v] {
+				icon.SetResource(theme.ConfirmIcon())
+			} else {
+				icon.SetResource(nil)
+			}
+		},
+	)
+	sd.List.OnSelected = func(id widget.ListItemID) {
+		sd.List.UnselectAll()
+		if id >= len(sd.filtered) {
+			return
+		}
+		v := sd.filtered[id]
+		if !cfg.Multiple {
+			sd.Hide()
+			sd.selectItems([]T{v})
+			return
+		}
+		if sd.selected[v] {
+			delete(sd.selected, v)
+		} else {
+			sd.selected[v] = true
+		}
+		sd.List.RefreshItem(id)
+	}
+	sd.List.HideSeparators = true
+	sd.Entry = widget.NewEntry()
+	sd.Entry.PlaceHolder = "Type to start searching..."
+	sd.Entry.ActionItem = cfg.IconButton(theme.CancelIcon(), func() {
+		sd.Entry.SetText("")
+	})
+	sd.Entry.OnChanged = func(search string) {
+		sd.filter(search)
+	}
+	sd.Clear = widget.NewButton(cfg.ClearLabel, func() {
+		sd.Hide()
+		sd.selectItems([]T{})
+	})
+	if len(sd.selected) > 0 {
+		if !cfg.Multiple {
+			sd.Entry.Disable()
+		}
+		sd.Clear.Show()
+	} else {
+		sd.Clear.Hide()
+	}
+	empty := widget.NewLabel("No entries")
+	empty.Importance = widget.LowImportance
+	if len(cfg.Items) == 0 || cfg.NoEntries {
+		empty.Show()
+		sd.Entry.Disable()
+	} else {
+		empty.Hide()
+	}
+	buttons := container.NewHBox(widget.NewButton("Cancel", func() {
+		sd.Hide()
+	}))
+	if cfg.Multiple {
+		sd.Confirm = widget.NewButtonWithIcon("Select", theme.ConfirmIcon(), func() {
+			sd.Hide()
+			selected := make([]T, 0)
+			for _, v := range cfg.Items {
+				if sd.selected[v] {
+					selected = append(selected, v)
+				}
+			}
+			sd.selectItems(selected)
+		})
+		sd.Confirm.Importance = widget.HighImportance
+		buttons.Add(sd.Confirm)
+	}
+	c := container.NewBorder(
+		container.NewBorder(
+			nil,
+			sd.Clear,
+			nil,
+			buttons,
+			sd.Entry,
+		),
+		empty,
+		nil,
+		nil,
+		sd.List,
+	)
+	sd.d = dialog.NewCustomWithoutButtons(cfg.Title, c, cfg.Window)
+	return sd
+}
+
+// Show shows the dialog and focuses the search entry.
+func (sd *Dialog[T]) Show() {
+	_, s := sd.cfg.Window.Canvas().InteractiveArea()
+	if fyne.CurrentDevice().IsMobile() {
+		sd.d.Resize(fyne.NewSize(s.Width, s.Height))
+	} else {
+		h := s.Height * 0.8
+		if h < 400 {
+			h = 400
+		}
+		sd.d.Resize(fyne.NewSize(600, h))
+	}
+	sd.d.Show()
+	sd.cfg.Window.Canvas().Focus(sd.Entry)
+}
+
+// Hide hides the dialog.
+func (sd *Dialog[T]) Hide() {
+	sd.d.Hide()
+}
+
+// filter shows the items matching search, ranked by their score.
+// All items are shown when search is shorter than [minSearchLength].
+func (sd *Dialog[T]) filter(search string) {
+	if len(search) < minSearchLength {
+		sd.filtered = sd.cfg.Items
+		sd.highlights = nil
+		sd.List.Refresh()
+		return
+	}
+	type match struct {
+		item      T
+		positions []int
+		score     int
+	}
+	matches := make([]match, 0)
+	for _, v := range sd.cfg.Items {
+		score, positions, ok := sd.cfg.Match(sd.cfg.ItemText(v), search)
+		if ok {
+			matches = append(matches, match{item: v, positions: positions, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	sd.filtered = make([]T, len(matches))
+	sd.highlights = make([][]int, len(matches))
+	for i, m := range matches {
+		sd.filtered[i] = m.item
+		sd.highlights[i] = m.positions
+	}
+	sd.List.ScrollToTop()
+	sd.List.Refresh()
+}
+
+func (sd *Dialog[T]) selectItems(selected []T) {
+	if sd.cfg.OnSelected != nil {
+		sd.cfg.OnSelected(selected)
+	}
+}
+
+// highlightSegments returns the segments for rendering text with the runes at positions highlighted.
+func highlightSegments(text string, positions []int) []widget.RichTextSegment {
+	if len(positions) == 0 {
+		return []widget.RichTextSegment{&widget.TextSegment{Text: text, Style: widget.RichTextStyleInline}}
+	}
+	highlighted := make(map[int]bool)
+	for _, p := range positions {
+		highlighted[p] = true
+	}
+	highlightStyle := widget.RichTextStyle{
+		ColorName: theme.ColorNamePrimary,
+		Inline:    true,
+		TextStyle: fyne.TextStyle{Bold: true},
+	}
+	segments := make([]widget.RichTextSegment, 0)
+	var current []rune
+	var currentHighlighted bool
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		style := widget.RichTextStyleInline
+		if currentHighlighted {
+			style = highlightStyle
+		}
+		segments = append(segments, &widget.TextSegment{Text: string(current), Style: style})
+		current = nil
+	}
+	i := 0
+	for _, r := range text {
+		if highlighted[i] != currentHighlighted {
+			flush()
+			currentHighlighted = highlighted[i]
+		}
+		current = append(current, r)
+		i++
+	}
+	flush()
+	return segments
+}
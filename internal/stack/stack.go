@@ -9,13 +9,13 @@ import (
 var ErrEmpty = errors.New("empty stack")
 
 // Stack represents a basic stack which can be used concurrently.
-type Stack[T any] struct {
+type Stack[T comparable] struct {
 	mu sync.Mutex
 	s  []T
 }
 
 // New returns a new [Stack].
-func New[T any]() *Stack[T] {
+func New[T comparable]() *Stack[T] {
 	st := &Stack[T]{s: make([]T, 0)}
 	return st
 }
@@ -40,6 +40,32 @@ func (st *Stack[T]) Pop() (T, error) {
 	return v, nil
 }
 
+// Peek returns the item from the top of the stack without removing it
+// or an error when the stack is empty.
+func (st *Stack[T]) Peek() (T, error) {
+	var v T
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.s) == 0 {
+		return v, ErrEmpty
+	}
+	return st.s[len(st.s)-1], nil
+}
+
+// Remove removes the topmost occurrence of an item from the stack
+// and reports whether it was found.
+func (st *Stack[T]) Remove(v T) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for i := len(st.s) - 1; i >= 0; i-- {
+		if st.s[i] == v {
+			st.s = append(st.s[:i], st.s[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Size returns the number of items in the stack.
 func (st *Stack[T]) Size() int {
 	st.mu.Lock()
@@ -26,6 +26,42 @@ func TestStack(t *testing.T) {
 		_, err := s.Pop()
 		assert.ErrorIs(t, stack.ErrEmpty, err)
 	})
+	t.Run("can peek at top item", func(t *testing.T) {
+		st := stack.New[int]()
+		st.Push(99)
+		st.Push(42)
+		v, err := st.Peek()
+		if assert.NoError(t, err) {
+			assert.Equal(t, 42, v)
+		}
+		assert.Equal(t, 2, st.Size())
+	})
+	t.Run("should return specific error when trying to peek at empty stack", func(t *testing.T) {
+		s := stack.New[int]()
+		_, err := s.Peek()
+		assert.ErrorIs(t, stack.ErrEmpty, err)
+	})
+	t.Run("can remove item from the middle", func(t *testing.T) {
+		st := stack.New[int]()
+		st.Push(1)
+		st.Push(2)
+		st.Push(3)
+		assert.True(t, st.Remove(2))
+		v, err := st.Pop()
+		if assert.NoError(t, err) {
+			assert.Equal(t, 3, v)
+		}
+		v, err = st.Pop()
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, v)
+		}
+	})
+	t.Run("should report when item to remove was not found", func(t *testing.T) {
+		st := stack.New[int]()
+		st.Push(1)
+		assert.False(t, st.Remove(2))
+		assert.Equal(t, 1, st.Size())
+	})
 	t.Run("should return correct stack size", func(t *testing.T) {
 		s := stack.New[int]()
 		s.Push(99)
@@ -0,0 +1,25 @@
+package modal
+
+import "time"
+
+// clock is the source of time for modals. It can be replaced in tests.
+type clock interface {
+	// AfterFunc calls f in its own goroutine after duration d.
+	AfterFunc(d time.Duration, f func()) timer
+	Now() time.Time
+}
+
+// timer is a timer started with [clock.AfterFunc].
+type timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) AfterFunc(d time.Duration, f func()) timer {
+	return time.AfterFunc(d, f)
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,85 @@
+package modal
+
+import (
+	"fmt"
+	"time"
+)
+
+// estimator estimates the remaining time of an action from its progress.
+// Progress is measured in the value range of a progress indicator, e.g. the number of processed items.
+type estimator struct {
+	clock   clock
+	max     float64
+	min     float64
+	started time.Time
+	value   float64
+}
+
+func newEstimator(c clock, min, max float64) *estimator {
+	e := &estimator{clock: c, min: min, max: max}
+	e.reset()
+	return e
+}
+
+// reset restarts the estimation from the current time.
+func (e *estimator) reset() {
+	e.started = e.clock.Now()
+	e.value = e.min
+}
+
+// setValue updates the current progress value.
+func (e *estimator) setValue(v float64) {
+	e.value = v
+}
+
+// elapsed returns the time since the estimation started.
+func (e *estimator) elapsed() time.Duration {
+	return e.clock.Now().Sub(e.started)
+}
+
+// rate returns the average progress per second since the estimation started.
+func (e *estimator) rate() float64 {
+	s := e.elapsed().Seconds()
+	if s <= 0 {
+		return 0
+	}
+	return (e.value - e.min) / s
+}
+
+// remaining returns the estimated remaining time
+// and reports whether an estimate is available.
+func (e *estimator) remaining() (time.Duration, bool) {
+	r := e.rate()
+	if r <= 0 {
+		return 0, false
+	}
+	left := e.max - e.value
+	if left < 0 {
+		left = 0
+	}
+	return time.Duration(left / r * float64(time.Second)), true
+}
+
+// String returns a summary of the estimation for showing to users.
+func (e *estimator) String() string {
+	s := fmt.Sprintf("Elapsed: %s", formatDuration(e.elapsed()))
+	if d, ok := e.remaining(); ok {
+		s += fmt.Sprintf(" | %.1f/s | Remaining: %s", e.rate(), formatDuration(d))
+	}
+	return s
+}
+
+// formatDuration returns a duration formatted as mm:ss or h:mm:ss.
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	s := int(d % time.Minute / time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
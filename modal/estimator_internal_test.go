@@ -0,0 +1,77 @@
+package modal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator(t *testing.T) {
+	t.Run("should report no estimate before any progress", func(t *testing.T) {
+		c := newFakeClock()
+		e := newEstimator(c, 0, 100)
+		c.Advance(5 * time.Second)
+		_, ok := e.remaining()
+		assert.False(t, ok)
+		assert.Equal(t, 5*time.Second, e.elapsed())
+		assert.Equal(t, "Elapsed: 00:05", e.String())
+	})
+	t.Run("should estimate remaining time from average rate", func(t *testing.T) {
+		c := newFakeClock()
+		e := newEstimator(c, 0, 100)
+		c.Advance(10 * time.Second)
+		e.setValue(25)
+		assert.InDelta(t, 2.5, e.rate(), 0.001)
+		d, ok := e.remaining()
+		if assert.True(t, ok) {
+			assert.Equal(t, 30*time.Second, d)
+		}
+		assert.Equal(t, "Elapsed: 00:10 | 2.5/s | Remaining: 00:30", e.String())
+	})
+	t.Run("should respect minimum of range", func(t *testing.T) {
+		c := newFakeClock()
+		e := newEstimator(c, 50, 150)
+		c.Advance(10 * time.Second)
+		e.setValue(100)
+		d, ok := e.remaining()
+		if assert.True(t, ok) {
+			assert.Equal(t, 10*time.Second, d)
+		}
+	})
+	t.Run("should not report negative remaining time", func(t *testing.T) {
+		c := newFakeClock()
+		e := newEstimator(c, 0, 10)
+		c.Advance(time.Second)
+		e.setValue(20)
+		d, ok := e.remaining()
+		if assert.True(t, ok) {
+			assert.Equal(t, time.Duration(0), d)
+		}
+	})
+	t.Run("can reset", func(t *testing.T) {
+		c := newFakeClock()
+		e := newEstimator(c, 0, 10)
+		c.Advance(time.Second)
+		e.setValue(5)
+		e.reset()
+		assert.Equal(t, time.Duration(0), e.elapsed())
+		assert.Equal(t, 0.0, e.rate())
+	})
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00"},
+		{-time.Second, "00:00"},
+		{1500 * time.Millisecond, "00:02"},
+		{61 * time.Second, "01:01"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, formatDuration(tc.d))
+	}
+}
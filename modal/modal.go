@@ -0,0 +1,122 @@
+/*
+Package modal defines modals for the Fyne GUI toolkit.
+
+# Modals
+
+Modals are similar to Fyne dialogs, but do not require user interaction.
+They are useful when you have a longer running process that the user needs to wait for before he can continue. e.g. opening a large file.
+
+# Progress modals
+
+Progress modals are modals that show a progress indicator while an action function is running.
+The are several variant, which all share a similar API:
+  - Title and message
+  - Action function callback that return an error
+  - Callback hooks for success and error, e.g. to inform the user about an error
+  - Start() method is called to start the action
+
+Note that the action function will always be run as a goroutine.
+
+A progress modal can be used similar to Fyne dialogs:
+
+	m := kxmodal.NewProgressInfinite("Loading file", "Loading file XX. Please wait.", func() error {
+		time.Sleep(3 * time.Second)  // simulate a long running process
+		return nil
+	}, w)
+	m.Start()
+
+The context variants pass a [context.Context] to the action function instead of a canceled channel.
+The context is canceled when the user presses the cancel button or when the parent context is canceled.
+Actions should abort when the context is done and return the context's error,
+so the OnError callback can tell a cancellation apart from a failure:
+
+	m := kxmodal.NewProgressInfiniteWithContext(ctx, "Loading file", "Please wait.", func(ctx context.Context) error {
+		return loadFile(ctx, "example.txt")
+	}, w)
+	m.OnError = func(err error) {
+		if errors.Is(err, context.Canceled) {
+			return // canceled by the user
+		}
+		dialog.ShowError(err, w)
+	}
+	m.Start()
+
+# Configurable progress modal
+
+All variants are built on [Progress], which can be configured with options
+to cover combinations the variants do not offer.
+The action function receives a [Reporter] for updating the progress indicator
+and the message shown to the user:
+
+	m := kxmodal.New("Importing", "Please wait.", func(ctx context.Context, r *kxmodal.Reporter) error {
+		r.SetInfinite(true) // counting files first
+		files := findFiles()
+		r.SetInfinite(false)
+		r.SetMessage("Importing files...")
+		for i, f := range files {
+			r.SetDetail(f)
+			if err := importFile(ctx, f); err != nil {
+				return err
+			}
+			r.SetValue(float64(i+1) / float64(len(files)))
+		}
+		return nil
+	}, w, kxmodal.WithCancelLabel("Stop"))
+	m.Start()
+
+By default the OnSuccess and OnError callbacks are run on the goroutine of the action function.
+With the option [WithCallbacksOnMain] they are run on the Fyne main thread instead,
+so they can update widgets directly.
+
+[NewWithResult] creates a modal whose action function returns a result,
+which is passed to the OnSuccess callback:
+
+	m := kxmodal.NewWithResult("Loading", "Please wait.", func(ctx context.Context, r *kxmodal.Reporter) (*Document, error) {
+		return loadDocument(ctx, "example.txt")
+	}, w, kxmodal.WithInfinite(), kxmodal.WithCallbacksOnMain())
+	m.OnSuccess = func(doc *Document) {
+		editor.SetText(doc.Text)
+	}
+	m.Start()
+
+To avoid a flashing modal for fast actions, the option [WithShowDelay] shows the modal
+only when the action takes longer than the delay,
+and [WithMinDisplay] keeps a modal visible for a minimum time once it is shown.
+
+With the option [WithRetry] a failed action does not close the modal.
+Instead the modal shows the error with buttons for retrying the action and for closing the modal.
+The [RetryPolicy] defines the maximum number of attempts and an optional backoff delay between attempts.
+
+# Waiting for a modal
+
+All progress modals can be awaited, e.g. to run several modals in sequence from a goroutine:
+
+	m1 := kxmodal.NewProgressInfinite("Step 1", "Please wait.", step1, w)
+	m2 := kxmodal.NewProgressInfinite("Step 2", "Please wait.", step2, w)
+	m1.Start()
+	go func() {
+		if err := m1.Wait(); err != nil {
+			return
+		}
+		fyne.Do(m2.Start)
+	}()
+
+Done returns a channel that is closed once the action has finished and the callbacks have returned.
+Note that Wait must not be called from the Fyne main thread.
+
+# Overlays
+
+An [Overlay] shows the progress indicator of a modal on top of a single container instead of a dialog,
+e.g. when only one panel of a window is loading. The rest of the window stays interactive.
+Overlays share the action function, callbacks and options of progress modals.
+
+# Running several tasks
+
+[NewTasks] creates a modal that runs several named tasks with a configurable concurrency.
+It shows a row with a progress indicator for each task and an overall progress indicator.
+Errors of failed tasks are reported together as [TaskErrors].
+*/
+package modal
+
+// openDialogs keeps track of the open dialogs of all modals.
+var openDialogs = newDialogRegistry()
@@ -0,0 +1,149 @@
+package modal
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Overlay is a widget that shows a progress indicator on top of its content while an action function is running.
+//
+// Unlike progress modals an overlay only covers its content, e.g. a single panel which is loading,
+// and the rest of the window stays interactive.
+// The content is dimmed and can not be tapped while the action is running.
+//
+// Overlays share the action function, callbacks and options of [Progress] modals:
+//
+//	o := kxmodal.NewOverlay(list, "Loading items...", func(ctx context.Context, r *kxmodal.Reporter) error {
+//		return loadItems(ctx)
+//	}, kxmodal.WithInfinite())
+//	w.SetContent(container.NewHSplit(o, details))
+//	o.Start()
+type Overlay struct {
+	widget.BaseWidget
+
+	// Optional callback when the action failed.
+	// Returns an error matching [context.Canceled] when the action was canceled
+	// and the action function returned the error of its context.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	content fyne.CanvasObject
+	m       *Progress
+}
+
+// NewOverlay returns a new [Overlay] for content configured with options.
+// The options are the same as for [New].
+func NewOverlay(content fyne.CanvasObject, message string, action func(ctx context.Context, r *Reporter) error, options ...Option) *Overlay {
+	w := &Overlay{content: content}
+	w.ExtendBaseWidget(w)
+	w.m = newProgress(message, action, nil, options...)
+	w.m.layer = newOverlayLayer(w.m.content)
+	return w
+}
+
+// Start starts the action function and shows the progress indicator while it is running.
+func (w *Overlay) Start() {
+	w.m.OnError = w.OnError
+	w.m.OnSuccess = w.OnSuccess
+	w.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the progress indicator is hidden and the callbacks have returned.
+func (w *Overlay) Done() <-chan struct{} {
+	return w.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (w *Overlay) Result() error {
+	return w.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the overlay from closing.
+func (w *Overlay) Wait() error {
+	return w.m.Wait()
+}
+
+func (w *Overlay) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(w.content, w.m.layer))
+}
+
+// overlayLayer is the layer of an overlay, which dims the content and shows the progress view.
+// It absorbs all pointer events, so the content below can not be used.
+type overlayLayer struct {
+	widget.BaseWidget
+
+	bg    *canvas.Rectangle
+	panel *canvas.Rectangle
+	view  fyne.CanvasObject
+}
+
+var _ desktop.Hoverable = (*overlayLayer)(nil)
+var _ fyne.DoubleTappable = (*overlayLayer)(nil)
+var _ fyne.Scrollable = (*overlayLayer)(nil)
+var _ fyne.SecondaryTappable = (*overlayLayer)(nil)
+var _ fyne.Tappable = (*overlayLayer)(nil)
+
+func newOverlayLayer(view fyne.CanvasObject) *overlayLayer {
+	w := &overlayLayer{
+		bg:    canvas.NewRectangle(nil),
+		panel: canvas.NewRectangle(nil),
+		view:  view,
+	}
+	w.ExtendBaseWidget(w)
+	w.updateColors()
+	w.Hide()
+	return w
+}
+
+func (w *overlayLayer) Tapped(_ *fyne.PointEvent) {}
+
+func (w *overlayLayer) TappedSecondary(_ *fyne.PointEvent) {}
+
+func (w *overlayLayer) DoubleTapped(_ *fyne.PointEvent) {}
+
+func (w *overlayLayer) Scrolled(_ *fyne.ScrollEvent) {}
+
+func (w *overlayLayer) MouseIn(_ *desktop.MouseEvent) {}
+
+func (w *overlayLayer) MouseMoved(_ *desktop.MouseEvent) {}
+
+func (w *overlayLayer) MouseOut() {}
+
+func (w *overlayLayer) CreateRenderer() fyne.WidgetRenderer {
+	w.panel.CornerRadius = w.Theme().Size(theme.SizeNameInputRadius)
+	spacer := canvas.NewRectangle(nil)
+	spacer.SetMinSize(fyne.NewSize(200, 0))
+	c := container.NewStack(
+		w.bg,
+		container.NewCenter(container.NewStack(
+			w.panel,
+			container.NewPadded(container.NewStack(spacer, w.view)),
+		)),
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (w *overlayLayer) Refresh() {
+	w.updateColors()
+	w.bg.Refresh()
+	w.panel.Refresh()
+	w.BaseWidget.Refresh()
+}
+
+func (w *overlayLayer) updateColors() {
+	th := w.Theme()
+	v := fyne.CurrentApp().Settings().ThemeVariant()
+	w.bg.FillColor = th.Color(theme.ColorNameShadow, v)
+	w.panel.FillColor = th.Color(theme.ColorNameOverlayBackground, v)
+}
@@ -0,0 +1,98 @@
+package modal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlay(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should cover content while action is running", func(t *testing.T) {
+		var tappedContent, tappedOther bool
+		content := widget.NewButton("Content", func() {
+			tappedContent = true
+		})
+		other := widget.NewButton("Other", func() {
+			tappedOther = true
+		})
+		release := make(chan struct{})
+		done := make(chan struct{})
+		o := NewOverlay(content, "Loading", func(ctx context.Context, r *Reporter) error {
+			<-release
+			return nil
+		}, WithInfinite())
+		o.OnSuccess = func() {
+			close(done)
+		}
+		w := test.NewWindow(container.NewVBox(o, other))
+		defer w.Close()
+		w.Resize(fyne.NewSize(400, 400))
+		o.Start()
+		assert.True(t, o.m.layer.Visible())
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		test.TapCanvas(w.Canvas(), fyne.NewPos(10, 10))
+		assert.False(t, tappedContent)
+		test.Tap(other)
+		assert.True(t, tappedOther)
+		close(release)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.False(t, o.m.layer.Visible())
+		test.TapCanvas(w.Canvas(), fyne.NewPos(10, 10))
+		assert.True(t, tappedContent)
+	})
+	t.Run("should report error", func(t *testing.T) {
+		myErr := errors.New("failed")
+		errC := make(chan error, 1)
+		o := NewOverlay(widget.NewLabel("Content"), "Loading", func(ctx context.Context, r *Reporter) error {
+			return myErr
+		})
+		o.OnError = func(err error) {
+			errC <- err
+		}
+		w := test.NewWindow(o)
+		defer w.Close()
+		o.Start()
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, myErr)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.ErrorIs(t, o.Wait(), myErr)
+	})
+	t.Run("should report progress", func(t *testing.T) {
+		reported := make(chan struct{})
+		release := make(chan struct{})
+		o := NewOverlay(widget.NewLabel("Content"), "Loading", func(ctx context.Context, r *Reporter) error {
+			r.SetMessage("Parsing...")
+			r.SetValue(0.5)
+			close(reported)
+			<-release
+			return nil
+		})
+		w := test.NewWindow(o)
+		defer w.Close()
+		o.Start()
+		select {
+		case <-reported:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.Equal(t, "Parsing...", o.m.message.Text)
+		assert.Equal(t, 0.5, o.m.pb.Value)
+		close(release)
+		<-o.Done()
+	})
+}
@@ -1,277 +1,503 @@
-/*
-Package modal defines modals for the Fyne GUI toolkit.
-
-# Modals
-
-Modals are similar to Fyne dialogs, but do not require user interaction.
-They are useful when you have a longer running process that the user needs to wait for before he can continue. e.g. opening a large file.
-
-# Progress modals
-
-Progress modals are modals that show a progress indicator while an action function is running.
-The are several variant, which all share a similar API:
-  - Title and message
-  - Action function callback that return an error
-  - Callback hooks for success and error, e.g. to inform the user about an error
-  - Start() method is called to start the action
-
-Note that the action function will always be run as a goroutine.
-
-A progress modal can be used similar to Fyne dialogs:
-
-	m := kxmodal.NewProgressInfinite("Loading file", "Loading file XX. Please wait.", func() error {
-		time.Sleep(3 * time.Second)  // simulate a long running process
-		return nil
-	}, w)
-	m.Start()
-*/
 package modal
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
-	"github.com/ErikKalkoken/fyne-kx/internal/stack"
 )
 
-// Keeping a stack of opened dialogs to make sure they are closed in LIFO order
-// For more information see Fyne issue #5564
-var openDialogs *stack.Stack[*dialog.CustomDialog]
-
-func init() {
-	openDialogs = stack.New[*dialog.CustomDialog]()
-}
-
-// ProgressModal is a modal that shows a progress indicator while an action function is running.
-// The progress indicator must be updated by the action function.
-type ProgressModal struct {
+// Progress is a configurable modal that shows a progress indicator while an action function is running.
+// The progress indicator is updated by the action function through a [Reporter].
+type Progress struct {
 	// Optional callback when the action failed.
+	// Returns an error matching [context.Canceled] when the action was canceled
+	// and the action function returned the error of its context.
 	OnError func(err error)
 
 	// Optional callback when the action succeeded.
 	OnSuccess func()
 
-	action func(binding.Float) error
-	d      *dialog.CustomDialog
-	pb     *widget.ProgressBar
-	pg     binding.Float
+	action          func(context.Context, *Reporter) error
+	body            fyne.CanvasObject
+	callbacksOnMain bool
+	cancel          context.CancelFunc
+	cancelLabel     string
+	cancelable      bool
+	clock           clock
+	concurrency     int
+	content         fyne.CanvasObject
+	ctx             context.Context
+	d               *dialog.CustomDialog
+	detail          *widget.Label
+	done            chan struct{}
+	err             error
+	errorView       *fyne.Container
+	est             *estimator
+	infinite        bool
+	layer           *overlayLayer // set for overlays, which are shown instead of a dialog
+	max             float64
+	message         *widget.Label
+	min             float64
+	mu              sync.Mutex // guards done, err and running
+	parent          fyne.Window
+	minDisplay      time.Duration
+	pb              *widget.ProgressBar
+	pbi             *widget.ProgressBarInfinite
+	pg              binding.Float
+	progressView    *fyne.Container
+	retry           *retrier
+	running         bool
+	showDelay       time.Duration
+	showETA         bool
+	showTimer       timer
+	shown           bool
+	shownAt         time.Time
+	stats           *widget.Label
+	statsTimer      timer
+	stopped         bool
 }
 
-// NewProgress returns a new [ProgressModal] instance.
-func NewProgress(title, message string, action func(progress binding.Float) error, max float64, parent fyne.Window) *ProgressModal {
-	m := &ProgressModal{
-		action: action,
-		pg:     binding.NewFloat(),
+// Option is an option for configuring a [Progress] modal.
+type Option func(*Progress)
+
+// WithCallbacksOnMain runs the OnSuccess and OnError callbacks on the Fyne main thread,
+// so they can update widgets without wrapping their code in [fyne.Do].
+// By default the callbacks are run on the goroutine of the action function.
+func WithCallbacksOnMain() Option {
+	return func(m *Progress) {
+		m.callbacksOnMain = true
 	}
-	pb := widget.NewProgressBarWithData(m.pg)
-	pb.Max = max
-	m.pb = pb
-	content := container.NewVBox(widget.NewLabel(message), m.pb)
-	m.d = dialog.NewCustomWithoutButtons(title, content, parent)
-	return m
 }
 
-// Start starts the action function and shows the modal while it is running.
-func (m *ProgressModal) Start() {
-	openDialogs.Push(m.d)
-	m.d.Show()
-	go func() {
-		err := m.action(m.pg)
-		d, err2 := openDialogs.Pop()
-		if err2 == nil {
-			fyne.Do(func() {
-				d.Hide()
-			})
-		}
-		if err != nil {
-			if m.OnError != nil {
-				m.OnError(err)
-			}
-		} else {
-			if m.OnSuccess != nil {
-				m.OnSuccess()
-			}
-		}
-	}()
+// WithCancel adds a button for canceling the context passed to the action function.
+func WithCancel() Option {
+	return func(m *Progress) {
+		m.cancelable = true
+	}
 }
 
-// ProgressCancelModal is a modal that shows a progress indicator while a function is running.
-// The progress indicator is updated by the function.
-type ProgressCancelModal struct {
-	// Optional callback when the action failed.
-	OnError func(err error)
+// WithCancelLabel adds a button with a custom label for canceling the context passed to the action function.
+func WithCancelLabel(label string) Option {
+	return func(m *Progress) {
+		m.cancelable = true
+		m.cancelLabel = label
+	}
+}
 
-	// Optional callback when the action succeeded.
-	OnSuccess func()
+// WithContext sets the parent context of the context passed to the action function.
+// The default is [context.Background].
+func WithContext(ctx context.Context) Option {
+	return func(m *Progress) {
+		m.ctx = ctx
+	}
+}
 
-	action   func(binding.Float, chan struct{}) error
-	canceled chan struct{}
-	d        *dialog.CustomDialog
-	pb       *widget.ProgressBar
-	pg       binding.Float
-}
-
-// NewProgress returns a new [ProgressModal] instance.
-func NewProgressWithCancel(title, message string, action func(progress binding.Float, canceled chan struct{}) error, max float64, parent fyne.Window) *ProgressCancelModal {
-	m := &ProgressCancelModal{
-		action: action,
-		pg:     binding.NewFloat(),
-	}
-	pb := widget.NewProgressBarWithData(m.pg)
-	pb.Max = max
-	m.pb = pb
-	content := container.NewVBox(
-		widget.NewLabel(message),
-		m.pb,
-		container.NewPadded(),
-		container.NewCenter(widget.NewButton("Cancel", func() {
-			closeChannelIfOpen(m.canceled)
-		})))
-	m.d = dialog.NewCustomWithoutButtons(title, content, parent)
-	return m
+// WithETA shows the elapsed time, the rate of progress and the estimated remaining time
+// below the determinate progress indicator.
+// The rate is measured in the units of the progress value, e.g. processed items per second.
+func WithETA() Option {
+	return func(m *Progress) {
+		m.showETA = true
+	}
 }
 
-// Start starts the action function and shows the modal while it is running.
-func (m *ProgressCancelModal) Start() {
-	m.canceled = make(chan struct{})
-	openDialogs.Push(m.d)
-	m.d.Show()
-	go func() {
-		err := m.action(m.pg, m.canceled)
-		d, err2 := openDialogs.Pop()
-		if err2 == nil {
-			fyne.Do(func() {
-				d.Hide()
-			})
-		}
-		if err != nil {
-			if m.OnError != nil {
-				m.OnError(err)
-			}
-		} else {
-			closeChannelIfOpen(m.canceled)
-			if m.OnSuccess != nil {
-				m.OnSuccess()
-			}
-		}
-	}()
+// WithInfinite starts the modal with an infinite progress indicator.
+// The action function can switch to a determinate progress indicator with [Reporter.SetInfinite].
+func WithInfinite() Option {
+	return func(m *Progress) {
+		m.infinite = true
+	}
 }
 
-func closeChannelIfOpen(c chan struct{}) {
-	select {
-	case <-c:
-	default:
-		close(c)
+// WithMinDisplay keeps the modal visible for at least duration d once it is shown.
+// This avoids flicker when an action finishes shortly after the modal was shown.
+func WithMinDisplay(d time.Duration) Option {
+	return func(m *Progress) {
+		m.minDisplay = d
 	}
 }
 
-// ProgressInfiniteModal is a modal that shows an infinite progress indicator while a function is running.
-type ProgressInfiniteModal struct {
-	// Optional callback when the action failed.
-	OnError func(err error)
+// WithShowDelay shows the modal only when the action takes longer than duration d.
+// This avoids a flashing modal for fast actions.
+func WithShowDelay(d time.Duration) Option {
+	return func(m *Progress) {
+		m.showDelay = d
+	}
+}
 
-	// Optional callback when the action succeeded.
-	OnSuccess func()
+// WithRange sets the minimum and maximum value of the determinate progress indicator.
+// The default range is 0 to 1.
+func WithRange(min, max float64) Option {
+	return func(m *Progress) {
+		m.min = min
+		m.max = max
+	}
+}
 
-	action func() error
-	d      *dialog.CustomDialog
-	pb     *widget.ProgressBarInfinite
+// withBody adds an object to the content of a modal below the progress indicator.
+func withBody(o fyne.CanvasObject) Option {
+	return func(m *Progress) {
+		m.body = o
+	}
 }
 
-// NewProgressInfinite returns a new [ProgressInfiniteModal] instance.
-func NewProgressInfinite(title, message string, action func() error, parent fyne.Window) *ProgressInfiniteModal {
-	m := &ProgressInfiniteModal{
-		action: action,
-		pb:     widget.NewProgressBarInfinite(),
+// withClock replaces the clock of a modal. Used in tests.
+func withClock(c clock) Option {
+	return func(m *Progress) {
+		m.clock = c
+	}
+}
+
+// New returns a new [Progress] modal configured with options.
+// By default the modal shows a determinate progress indicator and has no cancel button.
+func New(title, message string, action func(ctx context.Context, r *Reporter) error, parent fyne.Window, options ...Option) *Progress {
+	m := newProgress(message, action, parent, options...)
+	m.d = dialog.NewCustomWithoutButtons(title, m.content, parent)
+	return m
+}
+
+// newProgress returns a new progress modal without its dialog.
+func newProgress(message string, action func(ctx context.Context, r *Reporter) error, parent fyne.Window, options ...Option) *Progress {
+	m := &Progress{
+		action:      action,
+		cancelLabel: "Cancel",
+		clock:       realClock{},
+		ctx:         context.Background(),
+		done:        make(chan struct{}),
+		max:         1,
+		parent:      parent,
+		pg:          binding.NewFloat(),
+	}
+	for _, o := range options {
+		o(m)
+	}
+	m.pb = widget.NewProgressBarWithData(m.pg)
+	m.pb.Min = m.min
+	m.pb.Max = m.max
+	m.pbi = widget.NewProgressBarInfinite()
+	m.est = newEstimator(m.clock, m.min, m.max)
+	m.stats = widget.NewLabel("")
+	m.stats.Importance = widget.LowImportance
+	// The value can also be set directly through the binding, e.g. by the action functions of the legacy modals.
+	m.pg.AddListener(binding.NewDataListener(func() {
+		v, err := m.pg.Get()
+		if err != nil {
+			return
+		}
+		m.est.setValue(v)
+		m.updateStats()
+	}))
+	m.setInfinite(m.infinite)
+	m.message = widget.NewLabel(message)
+	m.detail = widget.NewLabel("")
+	m.detail.Importance = widget.LowImportance
+	m.detail.Truncation = fyne.TextTruncateEllipsis
+	m.detail.Hide()
+	m.progressView = container.NewVBox(
+		m.message,
+		m.detail,
+		container.NewStack(m.pb, m.pbi),
+		m.stats,
+	)
+	if m.body != nil {
+		m.progressView.Add(m.body)
+	}
+	if m.cancelable {
+		m.progressView.Add(container.NewPadded())
+		m.progressView.Add(container.NewCenter(widget.NewButton(m.cancelLabel, func() {
+			if m.cancel != nil {
+				m.cancel()
+			}
+		})))
+	}
+	m.content = m.progressView
+	if m.retry != nil {
+		m.errorView = m.retry.makeErrorView()
+		m.errorView.Hide()
+		m.content = container.NewStack(m.progressView, m.errorView)
 	}
-	content := container.NewVBox(widget.NewLabel(message), m.pb)
-	m.d = dialog.NewCustomWithoutButtons(title, content, parent)
 	return m
 }
 
 // Start starts the action function and shows the modal while it is running.
-func (m *ProgressInfiniteModal) Start() {
-	openDialogs.Push(m.d)
-	m.d.Show()
+// Calling Start again while the action is running has no effect.
+func (m *Progress) Start() {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return
+	}
+	m.running = true
+	select {
+	case <-m.done:
+		m.done = make(chan struct{}) // restarting a finished modal
+		m.err = nil
+	default:
+	}
+	m.mu.Unlock()
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancel = cancel
+	m.shown = false
+	m.stopped = false
+	if m.showDelay > 0 {
+		m.showTimer = m.clock.AfterFunc(m.showDelay, func() {
+			fyne.Do(m.show)
+		})
+	} else {
+		m.show()
+	}
+	if m.showETA {
+		m.est.reset()
+		m.updateStats()
+		m.scheduleStats()
+	}
 	go func() {
-		err := m.action()
-		d, err2 := openDialogs.Pop()
-		if err2 == nil {
-			fyne.Do(func() {
-				d.Hide()
+		defer cancel()
+		err := m.run(ctx)
+		closed := make(chan struct{})
+		fyne.Do(func() {
+			m.stop(func() {
+				close(closed)
+			})
+		})
+		<-closed
+		if m.callbacksOnMain {
+			fyne.DoAndWait(func() {
+				m.finish(err)
 			})
-		}
-		if err != nil {
-			if m.OnError != nil {
-				m.OnError(err)
-			}
 		} else {
-			if m.OnSuccess != nil {
-				m.OnSuccess()
-			}
+			m.finish(err)
 		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.err = err
+		m.running = false
+		close(m.done)
 	}()
 }
 
-// ProgressInfiniteCancelModal is a modal that shows an infinite progress indicator while a function is running.
-// The modal has a button for canceling the function.
-type ProgressInfiniteCancelModal struct {
-	// Optional callback when the action failed.
-	OnError func(err error)
+// run runs the action function and retries it when a retry policy is configured.
+func (m *Progress) run(ctx context.Context) error {
+	for attempt := 1; ; attempt++ {
+		err := m.action(ctx, &Reporter{t: m})
+		if err == nil || m.retry == nil || ctx.Err() != nil {
+			return err
+		}
+		if !m.retry.askRetry(ctx, m, err, attempt) {
+			return err
+		}
+		fyne.Do(m.showProgressView)
+		if err := m.retry.wait(ctx, m.clock, attempt); err != nil {
+			return err
+		}
+	}
+}
 
-	// Optional callback when the action succeeded.
-	OnSuccess func()
+func (m *Progress) showProgressView() {
+	m.errorView.Hide()
+	m.progressView.Show()
+	m.setValue(m.min)
+	m.est.reset()
+}
 
-	action   func(chan struct{}) error
-	canceled chan struct{}
-	d        *dialog.CustomDialog
-	pb       *widget.ProgressBarInfinite
-}
-
-// NewProgressInfiniteWithCancel returns a new [ProgressInfiniteCancelModal] instance.
-// The action function needs to check the canceled channel and abort if it is closed.
-func NewProgressInfiniteWithCancel(
-	title, message string, action func(canceled chan struct{}) error, parent fyne.Window,
-) *ProgressInfiniteCancelModal {
-	m := &ProgressInfiniteCancelModal{
-		action: action,
-		pb:     widget.NewProgressBarInfinite(),
-	}
-	content := container.NewVBox(
-		widget.NewLabel(message),
-		m.pb,
-		container.NewPadded(),
-		container.NewCenter(widget.NewButton("Cancel", func() {
-			closeChannelIfOpen(m.canceled)
-		})))
-	m.d = dialog.NewCustomWithoutButtons(title, content, parent)
-	return m
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *Progress) Done() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.done
 }
 
-// Start starts the action function and shows the modal while it is running.
-func (m *ProgressInfiniteCancelModal) Start() {
-	m.canceled = make(chan struct{})
-	openDialogs.Push(m.d)
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *Progress) Result() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *Progress) Wait() error {
+	<-m.Done()
+	return m.Result()
+}
+
+// show shows the modal unless the action has already stopped.
+func (m *Progress) show() {
+	if m.stopped || m.shown {
+		return
+	}
+	m.shown = true
+	m.shownAt = m.clock.Now()
+	if m.layer != nil {
+		m.layer.Show()
+		return
+	}
+	openDialogs.push(m.parent, m.d)
 	m.d.Show()
-	go func() {
-		err := m.action(m.canceled)
-		d, err2 := openDialogs.Pop()
-		if err2 == nil {
-			fyne.Do(func() {
-				d.Hide()
-			})
-		}
-		if err != nil {
-			if m.OnError != nil {
-				m.OnError(err)
-			}
+}
+
+// stop closes the modal after the action has stopped and calls closed afterwards.
+// The modal is closed no earlier than the minimum display time.
+// The dialog of a modal is hidden once all modals opened after it in the same window are closed.
+func (m *Progress) stop(closed func()) {
+	m.stopped = true
+	if m.showTimer != nil {
+		m.showTimer.Stop()
+		m.showTimer = nil
+	}
+	if m.statsTimer != nil {
+		m.statsTimer.Stop()
+		m.statsTimer = nil
+	}
+	if !m.shown {
+		closed()
+		return
+	}
+	hide := func() {
+		if m.layer != nil {
+			m.layer.Hide()
 		} else {
-			closeChannelIfOpen(m.canceled)
-			if m.OnSuccess != nil {
-				m.OnSuccess()
+			for _, d := range openDialogs.close(m.parent, m.d) {
+				d.Hide()
 			}
 		}
-	}()
+		closed()
+	}
+	remaining := m.minDisplay - m.clock.Now().Sub(m.shownAt)
+	if remaining > 0 {
+		m.clock.AfterFunc(remaining, func() {
+			fyne.Do(hide)
+		})
+		return
+	}
+	hide()
+}
+
+// finish calls the callback for the outcome of the action.
+func (m *Progress) finish(err error) {
+	if err != nil {
+		if m.OnError != nil {
+			m.OnError(err)
+		}
+	} else {
+		if m.OnSuccess != nil {
+			m.OnSuccess()
+		}
+	}
+}
+
+// scheduleStats updates the stats every second, so the elapsed time keeps moving
+// when the action does not report progress. It stops when the action has stopped.
+func (m *Progress) scheduleStats() {
+	m.statsTimer = m.clock.AfterFunc(time.Second, func() {
+		fyne.Do(func() {
+			if m.stopped {
+				return
+			}
+			m.updateStats()
+			m.scheduleStats()
+		})
+	})
+}
+
+func (m *Progress) setValue(v float64) {
+	m.pg.Set(v)
+	m.est.setValue(v)
+	m.updateStats()
+}
+
+func (m *Progress) updateStats() {
+	if !m.showETA || m.infinite {
+		m.stats.Hide()
+		return
+	}
+	m.stats.SetText(m.est.String())
+	m.stats.Show()
+}
+
+func (m *Progress) setMessage(text string) {
+	m.message.SetText(text)
+}
+
+func (m *Progress) setDetail(text string) {
+	m.detail.SetText(text)
+	if text == "" {
+		m.detail.Hide()
+	} else {
+		m.detail.Show()
+	}
+}
+
+func (m *Progress) setInfinite(infinite bool) {
+	m.infinite = infinite
+	if infinite {
+		m.pb.Hide()
+		m.pbi.Show()
+	} else {
+		m.pbi.Hide()
+		m.pb.Show()
+	}
+	m.updateStats()
+}
+
+// Reporter allows an action function to report its progress to a modal.
+// It is safe to call its methods from the goroutine running the action function.
+type Reporter struct {
+	mu *sync.Mutex // optional lock for serializing updates from concurrent reporters
+	t  reportTarget
+}
+
+// do runs f on the main thread.
+func (r *Reporter) do(f func()) {
+	if r.mu != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	fyne.Do(f)
+}
+
+// reportTarget is the part of a modal which is updated through a [Reporter].
+// Its methods are always called on the main thread.
+type reportTarget interface {
+	setDetail(text string)
+	setInfinite(infinite bool)
+	setMessage(text string)
+	setValue(v float64)
+}
+
+// SetValue sets the current value of the determinate progress indicator.
+func (r *Reporter) SetValue(v float64) {
+	r.do(func() {
+		r.t.setValue(v)
+	})
+}
+
+// SetInfinite switches between an infinite and a determinate progress indicator.
+func (r *Reporter) SetInfinite(infinite bool) {
+	r.do(func() {
+		r.t.setInfinite(infinite)
+	})
+}
+
+// SetMessage replaces the message shown above the progress indicator.
+func (r *Reporter) SetMessage(text string) {
+	r.do(func() {
+		r.t.setMessage(text)
+	})
+}
+
+// SetDetail sets a secondary line shown below the message, e.g. the name of the current file.
+// The detail line is hidden when text is empty.
+func (r *Reporter) SetDetail(text string) {
+	r.do(func() {
+		r.t.setDetail(text)
+	})
 }
@@ -0,0 +1,247 @@
+package modal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	action := func(ctx context.Context, r *Reporter) error {
+		return nil
+	}
+	t.Run("should create determinate modal by default", func(t *testing.T) {
+		m := New("Title", "Message", action, w)
+		assert.False(t, m.cancelable)
+		assert.True(t, m.pbi.Hidden)
+		assert.False(t, m.pb.Hidden)
+		assert.Equal(t, 0.0, m.pb.Min)
+		assert.Equal(t, 1.0, m.pb.Max)
+	})
+	t.Run("can create infinite modal", func(t *testing.T) {
+		m := New("Title", "Message", action, w, WithInfinite())
+		assert.False(t, m.pbi.Hidden)
+		assert.True(t, m.pb.Hidden)
+	})
+	t.Run("can set range", func(t *testing.T) {
+		m := New("Title", "Message", action, w, WithRange(10, 20))
+		assert.Equal(t, 10.0, m.pb.Min)
+		assert.Equal(t, 20.0, m.pb.Max)
+	})
+	t.Run("can create cancelable modal with custom label", func(t *testing.T) {
+		m := New("Title", "Message", action, w, WithCancelLabel("Stop"))
+		assert.True(t, m.cancelable)
+		assert.Equal(t, "Stop", m.cancelLabel)
+	})
+	t.Run("can switch between infinite and determinate", func(t *testing.T) {
+		m := New("Title", "Message", action, w)
+		m.setInfinite(true)
+		assert.False(t, m.pbi.Hidden)
+		assert.True(t, m.pb.Hidden)
+		m.setInfinite(false)
+		assert.True(t, m.pbi.Hidden)
+		assert.False(t, m.pb.Hidden)
+	})
+	t.Run("should hide detail by default", func(t *testing.T) {
+		m := New("Title", "Message", action, w)
+		assert.Equal(t, "Message", m.message.Text)
+		assert.True(t, m.detail.Hidden)
+	})
+	t.Run("can show and hide detail", func(t *testing.T) {
+		m := New("Title", "Message", action, w)
+		m.setDetail("Detail")
+		assert.Equal(t, "Detail", m.detail.Text)
+		assert.False(t, m.detail.Hidden)
+		m.setDetail("")
+		assert.True(t, m.detail.Hidden)
+	})
+}
+
+func TestProgressETA(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	action := func(ctx context.Context, r *Reporter) error {
+		return nil
+	}
+	t.Run("should hide stats by default", func(t *testing.T) {
+		m := New("Title", "Message", action, w)
+		m.setValue(0.5)
+		assert.True(t, m.stats.Hidden)
+	})
+	t.Run("should show stats when enabled", func(t *testing.T) {
+		c := newFakeClock()
+		m := New("Title", "Message", action, w, WithETA(), WithRange(0, 100), withClock(c))
+		c.Advance(10 * time.Second)
+		m.setValue(50)
+		assert.False(t, m.stats.Hidden)
+		assert.Equal(t, "Elapsed: 00:10 | 5.0/s | Remaining: 00:10", m.stats.Text)
+	})
+	t.Run("should update elapsed time regularly", func(t *testing.T) {
+		c := newFakeClock()
+		release := make(chan struct{})
+		m := New("Title", "Message", func(ctx context.Context, r *Reporter) error {
+			<-release
+			return nil
+		}, w, WithETA(), withClock(c))
+		m.Start()
+		c.Advance(3 * time.Second)
+		assert.Equal(t, "Elapsed: 00:03", m.stats.Text)
+		close(release)
+		assert.NoError(t, m.Wait())
+		assert.Equal(t, 0, c.Pending())
+	})
+	t.Run("should show ETA for legacy modal", func(t *testing.T) {
+		c := newFakeClock()
+		progressSet := make(chan struct{})
+		release := make(chan struct{})
+		pm := NewProgress("Title", "Message", func(p binding.Float) error {
+			p.Set(50)
+			close(progressSet)
+			<-release
+			return nil
+		}, 100, w)
+		pm.ShowETA = true
+		pm.m.clock = c
+		pm.m.est = newEstimator(c, 0, 100)
+		pm.Start()
+		<-progressSet
+		c.Advance(10 * time.Second)
+		assert.Equal(t, "Elapsed: 00:10 | 5.0/s | Remaining: 00:10", pm.m.stats.Text)
+		close(release)
+		assert.NoError(t, pm.Wait())
+	})
+	t.Run("should hide stats while infinite", func(t *testing.T) {
+		c := newFakeClock()
+		m := New("Title", "Message", action, w, WithETA(), withClock(c))
+		m.setInfinite(true)
+		assert.True(t, m.stats.Hidden)
+		m.setInfinite(false)
+		assert.False(t, m.stats.Hidden)
+	})
+}
+
+func TestReporter(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("can update message and detail", func(t *testing.T) {
+		m := New("Title", "Message", func(ctx context.Context, r *Reporter) error {
+			return nil
+		}, w)
+		r := &Reporter{t: m}
+		r.SetMessage("Parsing...")
+		r.SetDetail("file.txt")
+		assert.Equal(t, "Parsing...", m.message.Text)
+		assert.Equal(t, "file.txt", m.detail.Text)
+		assert.False(t, m.detail.Hidden)
+	})
+}
+
+func TestProgressShowDelay(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should not show modal when action finishes before delay", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		c := newFakeClock()
+		done := make(chan struct{})
+		m := New("Title", "Message", func(ctx context.Context, r *Reporter) error {
+			return nil
+		}, w, WithShowDelay(time.Second), withClock(c))
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.False(t, m.shown)
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		assert.Equal(t, 0, c.Pending())
+	})
+	t.Run("should show modal when action takes longer than delay", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		c := newFakeClock()
+		release := make(chan struct{})
+		done := make(chan struct{})
+		m := New("Title", "Message", func(ctx context.Context, r *Reporter) error {
+			<-release
+			return nil
+		}, w, WithShowDelay(time.Second), withClock(c))
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		c.Advance(time.Second)
+		assert.NotNil(t, w.Canvas().Overlays().Top())
+		close(release)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+}
+
+func TestProgressMinDisplay(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should keep modal visible for minimum duration", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		c := newFakeClock()
+		done := make(chan struct{})
+		m := New("Title", "Message", func(ctx context.Context, r *Reporter) error {
+			return nil
+		}, w, WithMinDisplay(500*time.Millisecond), withClock(c))
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		assert.Eventually(t, func() bool {
+			return c.Pending() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.NotNil(t, w.Canvas().Overlays().Top())
+		c.Advance(500 * time.Millisecond)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+	t.Run("should hide modal immediately when minimum duration has passed", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		c := newFakeClock()
+		release := make(chan struct{})
+		done := make(chan struct{})
+		m := New("Title", "Message", func(ctx context.Context, r *Reporter) error {
+			<-release
+			return nil
+		}, w, WithMinDisplay(500*time.Millisecond), withClock(c))
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		c.Advance(time.Second)
+		close(release)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.Nil(t, w.Canvas().Overlays().Top())
+	})
+}
@@ -0,0 +1,339 @@
+package modal_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	kxmodal "github.com/ErikKalkoken/fyne-kx/modal"
+)
+
+const timeout = 5 * time.Second
+
+func TestProgressInfiniteWithContext(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should call OnSuccess when action succeeded", func(t *testing.T) {
+		done := make(chan struct{})
+		m := kxmodal.NewProgressInfiniteWithContext(context.Background(), "Title", "Message", func(ctx context.Context) error {
+			return nil
+		}, w)
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should report cancellation of parent context as error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error, 1)
+		m := kxmodal.NewProgressInfiniteWithContext(ctx, "Title", "Message", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, w)
+		m.OnError = func(err error) {
+			errC <- err
+		}
+		m.Start()
+		cancel()
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should report other errors", func(t *testing.T) {
+		myErr := errors.New("failed")
+		errC := make(chan error, 1)
+		m := kxmodal.NewProgressInfiniteWithContext(context.Background(), "Title", "Message", func(ctx context.Context) error {
+			return myErr
+		}, w)
+		m.OnError = func(err error) {
+			errC <- err
+		}
+		m.Start()
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, myErr)
+			assert.NotErrorIs(t, err, context.Canceled)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+}
+
+func TestProgressWithContext(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should report progress and call OnSuccess", func(t *testing.T) {
+		done := make(chan struct{})
+		var pg binding.Float
+		m := kxmodal.NewProgressWithContext(context.Background(), "Title", "Message", func(ctx context.Context, p binding.Float) error {
+			pg = p
+			return p.Set(3)
+		}, 3, w)
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		select {
+		case <-done:
+			v, err := pg.Get()
+			if assert.NoError(t, err) {
+				assert.Equal(t, 3.0, v)
+			}
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+}
+
+func TestProgress(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should report progress and call OnSuccess", func(t *testing.T) {
+		done := make(chan struct{})
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			r.SetInfinite(true)
+			r.SetInfinite(false)
+			r.SetValue(0.5)
+			return nil
+		}, w)
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should report cancellation of parent context as error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error, 1)
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, w, kxmodal.WithContext(ctx), kxmodal.WithInfinite(), kxmodal.WithCancelLabel("Stop"))
+		m.OnError = func(err error) {
+			errC <- err
+		}
+		m.Start()
+		cancel()
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+}
+
+func TestProgressCallbacksOnMain(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should call OnSuccess", func(t *testing.T) {
+		done := make(chan struct{})
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			return nil
+		}, w, kxmodal.WithCallbacksOnMain())
+		m.OnSuccess = func() {
+			close(done)
+		}
+		m.Start()
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should call OnError", func(t *testing.T) {
+		myErr := errors.New("failed")
+		errC := make(chan error, 1)
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			return myErr
+		}, w, kxmodal.WithCallbacksOnMain())
+		m.OnError = func(err error) {
+			errC <- err
+		}
+		m.Start()
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, myErr)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+}
+
+func TestProgressResult(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should pass result to OnSuccess", func(t *testing.T) {
+		resultC := make(chan int, 1)
+		m := kxmodal.NewWithResult("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) (int, error) {
+			return 42, nil
+		}, w)
+		m.OnSuccess = func(v int) {
+			resultC <- v
+		}
+		m.Start()
+		select {
+		case v := <-resultC:
+			assert.Equal(t, 42, v)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should call OnError when action failed", func(t *testing.T) {
+		myErr := errors.New("failed")
+		errC := make(chan error, 1)
+		m := kxmodal.NewWithResult("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) (string, error) {
+			return "", myErr
+		}, w)
+		m.OnError = func(err error) {
+			errC <- err
+		}
+		m.Start()
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, myErr)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+}
+
+func TestProgressWait(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should return nil when action succeeded", func(t *testing.T) {
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			return nil
+		}, w)
+		m.Start()
+		err := m.Wait()
+		assert.NoError(t, err)
+		assert.NoError(t, m.Result())
+	})
+	t.Run("should return error when action failed", func(t *testing.T) {
+		myErr := errors.New("failed")
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			return myErr
+		}, w)
+		m.Start()
+		err := m.Wait()
+		assert.ErrorIs(t, err, myErr)
+		assert.ErrorIs(t, m.Result(), myErr)
+	})
+	t.Run("should close done channel after callbacks", func(t *testing.T) {
+		var called bool
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			return nil
+		}, w, kxmodal.WithCallbacksOnMain())
+		m.OnSuccess = func() {
+			called = true
+		}
+		m.Start()
+		select {
+		case <-m.Done():
+			assert.True(t, called)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("can restart a finished modal", func(t *testing.T) {
+		var count int
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			count++
+			return nil
+		}, w)
+		m.Start()
+		assert.NoError(t, m.Wait())
+		m.Start()
+		assert.NoError(t, m.Wait())
+		assert.Equal(t, 2, count)
+	})
+	t.Run("should ignore start while running", func(t *testing.T) {
+		var count atomic.Int32
+		release := make(chan struct{})
+		m := kxmodal.New("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) error {
+			count.Add(1)
+			<-release
+			return nil
+		}, w)
+		m.Start()
+		m.Start()
+		close(release)
+		assert.NoError(t, m.Wait())
+		assert.Equal(t, int32(1), count.Load())
+	})
+	t.Run("can wait for legacy variants", func(t *testing.T) {
+		myErr := errors.New("failed")
+		m := kxmodal.NewProgressInfinite("Title", "Message", func() error {
+			return myErr
+		}, w)
+		m.Start()
+		assert.ErrorIs(t, m.Wait(), myErr)
+	})
+}
+
+func TestProgressResultWait(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should return result when action succeeded", func(t *testing.T) {
+		m := kxmodal.NewWithResult("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) (string, error) {
+			return "done", nil
+		}, w)
+		m.Start()
+		v, err := m.Wait()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "done", v)
+		}
+	})
+	t.Run("should return error when action failed", func(t *testing.T) {
+		myErr := errors.New("failed")
+		m := kxmodal.NewWithResult("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) (string, error) {
+			return "ignored", myErr
+		}, w)
+		m.Start()
+		v, err := m.Wait()
+		assert.ErrorIs(t, err, myErr)
+		assert.Equal(t, "", v)
+	})
+	t.Run("should return zero value before action finished", func(t *testing.T) {
+		release := make(chan struct{})
+		m := kxmodal.NewWithResult("Title", "Message", func(ctx context.Context, r *kxmodal.Reporter) (int, error) {
+			<-release
+			return 42, nil
+		}, w)
+		m.Start()
+		v, err := m.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, v)
+		close(release)
+		v, err = m.Wait()
+		if assert.NoError(t, err) {
+			assert.Equal(t, 42, v)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package modal
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+)
+
+// ProgressResult is a [Progress] modal whose action function returns a result.
+// The result is passed to the OnSuccess callback.
+type ProgressResult[T any] struct {
+	// Optional callback when the action failed.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded. Receives the result of the action.
+	OnSuccess func(result T)
+
+	m      *Progress
+	result T
+}
+
+// NewWithResult returns a new [ProgressResult] modal configured with options.
+// The options are the same as for [New].
+func NewWithResult[T any](
+	title, message string, action func(ctx context.Context, r *Reporter) (T, error), parent fyne.Window, options ...Option,
+) *ProgressResult[T] {
+	m := &ProgressResult[T]{}
+	m.m = New(title, message, func(ctx context.Context, r *Reporter) error {
+		v, err := action(ctx, r)
+		if err != nil {
+			return err
+		}
+		m.result = v
+		return nil
+	}, parent, options...)
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressResult[T]) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = func() {
+		if m.OnSuccess != nil {
+			m.OnSuccess(m.result)
+		}
+	}
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressResult[T]) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the result and the error of the action function.
+// It returns the zero value of T when the action failed or has not yet finished.
+func (m *ProgressResult[T]) Result() (T, error) {
+	var zero T
+	select {
+	case <-m.m.Done():
+	default:
+		return zero, nil
+	}
+	if err := m.m.Result(); err != nil {
+		return zero, err
+	}
+	return m.result, nil
+}
+
+// Wait blocks until the action has finished and returns its result and error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressResult[T]) Wait() (T, error) {
+	<-m.m.Done()
+	return m.Result()
+}
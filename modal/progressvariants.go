@@ -0,0 +1,321 @@
+package modal
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// ProgressModal is a modal that shows a progress indicator while an action function is running.
+// The progress indicator must be updated by the action function.
+type ProgressModal struct {
+	// Optional callback when the action failed.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	// Whether to show the elapsed time, the rate of progress and the estimated remaining time.
+	ShowETA bool
+
+	m *Progress
+}
+
+// NewProgress returns a new [ProgressModal] instance.
+func NewProgress(title, message string, action func(progress binding.Float) error, max float64, parent fyne.Window) *ProgressModal {
+	m := &ProgressModal{}
+	m.m = New(title, message, func(_ context.Context, _ *Reporter) error {
+		return action(m.m.pg)
+	}, parent, WithRange(0, max))
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressModal) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = m.OnSuccess
+	m.m.showETA = m.ShowETA
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressModal) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *ProgressModal) Result() error {
+	return m.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressModal) Wait() error {
+	return m.m.Wait()
+}
+
+// ProgressCancelModal is a modal that shows a progress indicator while a function is running.
+// The progress indicator is updated by the function.
+type ProgressCancelModal struct {
+	// Optional callback when the action failed.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	// Whether to show the elapsed time, the rate of progress and the estimated remaining time.
+	ShowETA bool
+
+	m *Progress
+}
+
+// NewProgress returns a new [ProgressModal] instance.
+func NewProgressWithCancel(title, message string, action func(progress binding.Float, canceled chan struct{}) error, max float64, parent fyne.Window) *ProgressCancelModal {
+	m := &ProgressCancelModal{}
+	m.m = New(title, message, func(ctx context.Context, _ *Reporter) error {
+		return action(m.m.pg, canceledChannel(ctx))
+	}, parent, WithRange(0, max), WithCancel())
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressCancelModal) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = m.OnSuccess
+	m.m.showETA = m.ShowETA
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressCancelModal) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *ProgressCancelModal) Result() error {
+	return m.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressCancelModal) Wait() error {
+	return m.m.Wait()
+}
+
+// canceledChannel returns a channel which is closed once ctx is done.
+func canceledChannel(ctx context.Context) chan struct{} {
+	canceled := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(canceled)
+	}()
+	return canceled
+}
+
+// ProgressInfiniteModal is a modal that shows an infinite progress indicator while a function is running.
+type ProgressInfiniteModal struct {
+	// Optional callback when the action failed.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	m *Progress
+}
+
+// NewProgressInfinite returns a new [ProgressInfiniteModal] instance.
+func NewProgressInfinite(title, message string, action func() error, parent fyne.Window) *ProgressInfiniteModal {
+	m := &ProgressInfiniteModal{}
+	m.m = New(title, message, func(_ context.Context, _ *Reporter) error {
+		return action()
+	}, parent, WithInfinite())
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressInfiniteModal) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = m.OnSuccess
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressInfiniteModal) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *ProgressInfiniteModal) Result() error {
+	return m.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressInfiniteModal) Wait() error {
+	return m.m.Wait()
+}
+
+// ProgressInfiniteCancelModal is a modal that shows an infinite progress indicator while a function is running.
+// The modal has a button for canceling the function.
+type ProgressInfiniteCancelModal struct {
+	// Optional callback when the action failed.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	m *Progress
+}
+
+// NewProgressInfiniteWithCancel returns a new [ProgressInfiniteCancelModal] instance.
+// The action function needs to check the canceled channel and abort if it is closed.
+func NewProgressInfiniteWithCancel(
+	title, message string, action func(canceled chan struct{}) error, parent fyne.Window,
+) *ProgressInfiniteCancelModal {
+	m := &ProgressInfiniteCancelModal{}
+	m.m = New(title, message, func(ctx context.Context, _ *Reporter) error {
+		return action(canceledChannel(ctx))
+	}, parent, WithInfinite(), WithCancel())
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressInfiniteCancelModal) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = m.OnSuccess
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressInfiniteCancelModal) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *ProgressInfiniteCancelModal) Result() error {
+	return m.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressInfiniteCancelModal) Wait() error {
+	return m.m.Wait()
+}
+
+// ProgressContextModal is a modal that shows a progress indicator while a function is running.
+// The progress indicator is updated by the function.
+// The modal has a button for canceling the context passed to the function.
+type ProgressContextModal struct {
+	// Optional callback when the action failed.
+	// Returns an error matching [context.Canceled] when the action was canceled
+	// and the action function returned the error of its context.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	// Whether to show the elapsed time, the rate of progress and the estimated remaining time.
+	ShowETA bool
+
+	m *Progress
+}
+
+// NewProgressWithContext returns a new [ProgressContextModal] instance.
+// The context passed to the action is derived from ctx and is canceled
+// when the user presses the cancel button or when ctx is canceled.
+func NewProgressWithContext(
+	ctx context.Context, title, message string, action func(ctx context.Context, progress binding.Float) error, max float64, parent fyne.Window,
+) *ProgressContextModal {
+	m := &ProgressContextModal{}
+	m.m = New(title, message, func(ctx context.Context, _ *Reporter) error {
+		return action(ctx, m.m.pg)
+	}, parent, WithContext(ctx), WithRange(0, max), WithCancel())
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressContextModal) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = m.OnSuccess
+	m.m.showETA = m.ShowETA
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressContextModal) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *ProgressContextModal) Result() error {
+	return m.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressContextModal) Wait() error {
+	return m.m.Wait()
+}
+
+// ProgressInfiniteContextModal is a modal that shows an infinite progress indicator while a function is running.
+// The modal has a button for canceling the context passed to the function.
+type ProgressInfiniteContextModal struct {
+	// Optional callback when the action failed.
+	// Returns an error matching [context.Canceled] when the action was canceled
+	// and the action function returned the error of its context.
+	OnError func(err error)
+
+	// Optional callback when the action succeeded.
+	OnSuccess func()
+
+	m *Progress
+}
+
+// NewProgressInfiniteWithContext returns a new [ProgressInfiniteContextModal] instance.
+// The context passed to the action is derived from ctx and is canceled
+// when the user presses the cancel button or when ctx is canceled.
+func NewProgressInfiniteWithContext(
+	ctx context.Context, title, message string, action func(ctx context.Context) error, parent fyne.Window,
+) *ProgressInfiniteContextModal {
+	m := &ProgressInfiniteContextModal{}
+	m.m = New(title, message, func(ctx context.Context, _ *Reporter) error {
+		return action(ctx)
+	}, parent, WithContext(ctx), WithInfinite(), WithCancel())
+	return m
+}
+
+// Start starts the action function and shows the modal while it is running.
+func (m *ProgressInfiniteContextModal) Start() {
+	m.m.OnError = m.OnError
+	m.m.OnSuccess = m.OnSuccess
+	m.m.Start()
+}
+
+// Done returns a channel that is closed when the action has finished,
+// the modal is closed and the callbacks have returned.
+func (m *ProgressInfiniteContextModal) Done() <-chan struct{} {
+	return m.m.Done()
+}
+
+// Result returns the error returned by the action function.
+// It returns nil when the action succeeded or has not yet finished.
+func (m *ProgressInfiniteContextModal) Result() error {
+	return m.m.Result()
+}
+
+// Wait blocks until the action has finished and returns its error.
+// Wait must not be called from the Fyne main thread, since that would block the modal from closing.
+func (m *ProgressInfiniteContextModal) Wait() error {
+	return m.m.Wait()
+}
@@ -0,0 +1,81 @@
+package modal
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"github.com/ErikKalkoken/fyne-kx/internal/stack"
+)
+
+// dialogRegistry keeps a stack of open dialogs for each window
+// to make sure they are hidden in LIFO order, even when modals finish out of order.
+// For more information see Fyne issue #5564
+type dialogRegistry struct {
+	mu      sync.Mutex
+	closing map[*dialog.CustomDialog]bool
+	stacks  map[fyne.Window]*stack.Stack[*dialog.CustomDialog]
+}
+
+func newDialogRegistry() *dialogRegistry {
+	r := &dialogRegistry{
+		closing: make(map[*dialog.CustomDialog]bool),
+		stacks:  make(map[fyne.Window]*stack.Stack[*dialog.CustomDialog]),
+	}
+	return r
+}
+
+// push registers dialog d as the top-most open dialog of window w.
+func (r *dialogRegistry) push(w fyne.Window, d *dialog.CustomDialog) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closing[d] {
+		// Reopened before it was hidden, so it is still on the stack.
+		delete(r.closing, d)
+		return
+	}
+	st, ok := r.stacks[w]
+	if !ok {
+		st = stack.New[*dialog.CustomDialog]()
+		r.stacks[w] = st
+	}
+	st.Push(d)
+}
+
+// close marks dialog d of window w as closing and returns the dialogs, which can now be hidden.
+// A dialog can only be hidden once all dialogs opened after it in the same window are hidden.
+// The returned dialogs must be hidden in order.
+func (r *dialogRegistry) close(w fyne.Window, d *dialog.CustomDialog) []*dialog.CustomDialog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, ok := r.stacks[w]
+	if !ok {
+		return nil
+	}
+	r.closing[d] = true
+	var dialogs []*dialog.CustomDialog
+	for {
+		top, err := st.Peek()
+		if err != nil || !r.closing[top] {
+			break
+		}
+		st.Pop()
+		delete(r.closing, top)
+		dialogs = append(dialogs, top)
+	}
+	if st.Size() == 0 {
+		delete(r.stacks, w)
+	}
+	return dialogs
+}
+
+// size returns the number of open dialogs of window w.
+func (r *dialogRegistry) size(w fyne.Window) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, ok := r.stacks[w]
+	if !ok {
+		return 0
+	}
+	return st.Size()
+}
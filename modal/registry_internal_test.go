@@ -0,0 +1,165 @@
+package modal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialogRegistry(t *testing.T) {
+	test.NewTempApp(t)
+	makeDialog := func(w fyne.Window) *dialog.CustomDialog {
+		return dialog.NewCustomWithoutButtons("Title", widget.NewLabel("Content"), w)
+	}
+	t.Run("should return top dialog when closed", func(t *testing.T) {
+		r := newDialogRegistry()
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d1 := makeDialog(w)
+		d2 := makeDialog(w)
+		r.push(w, d1)
+		r.push(w, d2)
+		got := r.close(w, d2)
+		assert.Equal(t, []*dialog.CustomDialog{d2}, got)
+		assert.Equal(t, 1, r.size(w))
+	})
+	t.Run("should defer dialogs closed out of order", func(t *testing.T) {
+		r := newDialogRegistry()
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d1 := makeDialog(w)
+		d2 := makeDialog(w)
+		d3 := makeDialog(w)
+		r.push(w, d1)
+		r.push(w, d2)
+		r.push(w, d3)
+		assert.Empty(t, r.close(w, d1))
+		assert.Empty(t, r.close(w, d2))
+		got := r.close(w, d3)
+		assert.Equal(t, []*dialog.CustomDialog{d3, d2, d1}, got)
+		assert.Equal(t, 0, r.size(w))
+	})
+	t.Run("should keep dialogs of windows separate", func(t *testing.T) {
+		r := newDialogRegistry()
+		w1 := test.NewWindow(nil)
+		defer w1.Close()
+		w2 := test.NewWindow(nil)
+		defer w2.Close()
+		d1 := makeDialog(w1)
+		d2 := makeDialog(w2)
+		r.push(w1, d1)
+		r.push(w2, d2)
+		got := r.close(w1, d1)
+		assert.Equal(t, []*dialog.CustomDialog{d1}, got)
+		assert.Equal(t, 0, r.size(w1))
+		assert.Equal(t, 1, r.size(w2))
+	})
+	t.Run("should keep position of dialog reopened before it was hidden", func(t *testing.T) {
+		r := newDialogRegistry()
+		w := test.NewWindow(nil)
+		defer w.Close()
+		d1 := makeDialog(w)
+		d2 := makeDialog(w)
+		r.push(w, d1)
+		r.push(w, d2)
+		assert.Empty(t, r.close(w, d1))
+		r.push(w, d1)
+		assert.Equal(t, 2, r.size(w))
+		assert.Equal(t, []*dialog.CustomDialog{d2}, r.close(w, d2))
+		assert.Equal(t, []*dialog.CustomDialog{d1}, r.close(w, d1))
+	})
+	t.Run("should ignore dialogs of unknown windows", func(t *testing.T) {
+		r := newDialogRegistry()
+		w := test.NewWindow(nil)
+		defer w.Close()
+		assert.Empty(t, r.close(w, makeDialog(w)))
+	})
+	t.Run("can be used concurrently", func(t *testing.T) {
+		r := newDialogRegistry()
+		w := test.NewWindow(nil)
+		defer w.Close()
+		dialogs := make([]*dialog.CustomDialog, 100)
+		for i := range dialogs {
+			dialogs[i] = makeDialog(w)
+			r.push(w, dialogs[i])
+		}
+		var mu sync.Mutex
+		var hidden []*dialog.CustomDialog
+		var wg sync.WaitGroup
+		for _, d := range dialogs {
+			wg.Add(1)
+			go func(d *dialog.CustomDialog) {
+				defer wg.Done()
+				x := r.close(w, d)
+				mu.Lock()
+				defer mu.Unlock()
+				hidden = append(hidden, x...)
+			}(d)
+		}
+		wg.Wait()
+		assert.Equal(t, 0, r.size(w))
+		assert.Len(t, hidden, len(dialogs))
+	})
+}
+
+func TestOverlappingModals(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should hide dialogs in LIFO order when modals finish out of order", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		release1 := make(chan struct{})
+		release2 := make(chan struct{})
+		m1 := New("First", "Message", func(ctx context.Context, r *Reporter) error {
+			<-release1
+			return nil
+		}, w)
+		m2 := New("Second", "Message", func(ctx context.Context, r *Reporter) error {
+			<-release2
+			return nil
+		}, w)
+		m1.Start()
+		m2.Start()
+		assert.Len(t, w.Canvas().Overlays().List(), 2)
+		close(release1)
+		assert.NoError(t, m1.Wait())
+		assert.Len(t, w.Canvas().Overlays().List(), 2)
+		assert.Equal(t, 2, openDialogs.size(w))
+		close(release2)
+		assert.NoError(t, m2.Wait())
+		assert.Len(t, w.Canvas().Overlays().List(), 0)
+		assert.Equal(t, 0, openDialogs.size(w))
+	})
+	t.Run("should close many overlapping modals", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		const n = 20
+		modals := make([]*Progress, n)
+		releases := make([]chan struct{}, n)
+		for i := 0; i < n; i++ {
+			release := make(chan struct{})
+			releases[i] = release
+			modals[i] = New(fmt.Sprintf("Modal %d", i), "Message", func(ctx context.Context, r *Reporter) error {
+				select {
+				case <-release:
+				case <-time.After(5 * time.Second):
+				}
+				return nil
+			}, w)
+			modals[i].Start()
+		}
+		for _, i := range []int{3, 0, 19, 7, 12, 1, 18, 2, 4, 5, 6, 8, 9, 10, 11, 13, 14, 15, 16, 17} {
+			close(releases[i])
+			assert.NoError(t, modals[i].Wait())
+		}
+		assert.Len(t, w.Canvas().Overlays().List(), 0)
+		assert.Equal(t, 0, openDialogs.size(w))
+	})
+}
@@ -0,0 +1,155 @@
+package modal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// RetryPolicy defines how a failed action of a [Progress] modal can be retried.
+type RetryPolicy struct {
+	// Maximum number of attempts including the first one. Zero means no limit.
+	MaxAttempts int
+
+	// Optional function returning the delay before retrying after the given failed attempt.
+	// The first failed attempt is 1.
+	Backoff func(attempt int) time.Duration
+}
+
+// ExponentialBackoff returns a backoff function for a [RetryPolicy],
+// which starts with the base delay and doubles it for each further attempt up to the max delay.
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// WithRetry enables retrying failed actions.
+// When the action fails, the modal shows the error with buttons for retrying the action and for closing the modal.
+// OnError is called when the user closes the modal.
+// Canceled actions are not retried.
+func WithRetry(policy RetryPolicy) Option {
+	return func(m *Progress) {
+		m.retry = &retrier{
+			closeLabel: "Close",
+			policy:     policy,
+			retryLabel: "Retry",
+		}
+	}
+}
+
+// retrier implements retrying failed actions for a modal.
+type retrier struct {
+	asked       chan struct{} // optional channel signalled after the error view is shown. Used in tests.
+	closeButton *widget.Button
+	closeLabel  string
+	errorLabel  *widget.Label
+	policy      RetryPolicy
+	response    chan bool
+	retryButton *widget.Button
+	retryLabel  string
+}
+
+func (r *retrier) makeErrorView() *fyne.Container {
+	r.errorLabel = widget.NewLabel("")
+	r.errorLabel.Importance = widget.DangerImportance
+	r.errorLabel.Wrapping = fyne.TextWrapWord
+	r.closeButton = widget.NewButton(r.closeLabel, func() {
+		r.respond(false)
+	})
+	r.retryButton = widget.NewButton(r.retryLabel, func() {
+		r.respond(true)
+	})
+	r.retryButton.Importance = widget.HighImportance
+	return container.NewVBox(
+		r.errorLabel,
+		container.NewPadded(),
+		container.NewHBox(layout.NewSpacer(), r.closeButton, r.retryButton, layout.NewSpacer()),
+	)
+}
+
+func (r *retrier) respond(retry bool) {
+	if r.response == nil {
+		return
+	}
+	c := r.response
+	r.response = nil
+	c <- retry
+}
+
+// canRetry reports whether another attempt is allowed after the given failed attempt.
+func (r *retrier) canRetry(attempt int) bool {
+	return r.policy.MaxAttempts <= 0 || attempt < r.policy.MaxAttempts
+}
+
+// askRetry shows the error view of modal m and reports whether the user wants to retry.
+// It blocks until the user has responded or ctx is done.
+func (r *retrier) askRetry(ctx context.Context, m *Progress, err error, attempt int) bool {
+	response := make(chan bool, 1)
+	fyne.Do(func() {
+		r.response = response
+		r.errorLabel.SetText(r.errorText(err, attempt))
+		if r.canRetry(attempt) {
+			r.retryButton.Enable()
+		} else {
+			r.retryButton.Disable()
+		}
+		m.progressView.Hide()
+		m.errorView.Show()
+		m.show()
+	})
+	if r.asked != nil {
+		select {
+		case <-ctx.Done():
+		case r.asked <- struct{}{}:
+		}
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case retry := <-response:
+		return retry
+	}
+}
+
+func (r *retrier) errorText(err error, attempt int) string {
+	if r.policy.MaxAttempts > 0 {
+		return fmt.Sprintf("Attempt %d of %d failed: %s", attempt, r.policy.MaxAttempts, err)
+	}
+	return fmt.Sprintf("Attempt %d failed: %s", attempt, err)
+}
+
+// wait waits for the backoff delay after the given failed attempt.
+// It returns the error of the context when it is done while waiting.
+func (r *retrier) wait(ctx context.Context, c clock, attempt int) error {
+	if r.policy.Backoff == nil {
+		return nil
+	}
+	d := r.policy.Backoff(attempt)
+	if d <= 0 {
+		return nil
+	}
+	elapsed := make(chan struct{})
+	t := c.AfterFunc(d, func() {
+		close(elapsed)
+	})
+	select {
+	case <-ctx.Done():
+		t.Stop()
+		return ctx.Err()
+	case <-elapsed:
+		return nil
+	}
+}
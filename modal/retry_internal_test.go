@@ -0,0 +1,120 @@
+package modal
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressRetry(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	myErr := errors.New("failed")
+	newModal := func(action func(ctx context.Context, r *Reporter) error, options ...Option) *Progress {
+		m := New("Title", "Message", action, w, options...)
+		m.retry.asked = make(chan struct{})
+		return m
+	}
+	waitForErrorView := func(t *testing.T, m *Progress) {
+		select {
+		case <-m.retry.asked:
+			assert.True(t, m.errorView.Visible())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+	t.Run("can retry a failed action", func(t *testing.T) {
+		var attempts int32
+		m := newModal(func(ctx context.Context, r *Reporter) error {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				return myErr
+			}
+			return nil
+		}, WithRetry(RetryPolicy{}))
+		m.Start()
+		waitForErrorView(t, m)
+		assert.Equal(t, "Attempt 1 failed: failed", m.retry.errorLabel.Text)
+		test.Tap(m.retry.retryButton)
+		assert.NoError(t, m.Wait())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+	t.Run("should report error when closed", func(t *testing.T) {
+		errC := make(chan error, 1)
+		m := newModal(func(ctx context.Context, r *Reporter) error {
+			return myErr
+		}, WithRetry(RetryPolicy{}))
+		m.OnError = func(err error) {
+			errC <- err
+		}
+		m.Start()
+		waitForErrorView(t, m)
+		test.Tap(m.retry.closeButton)
+		select {
+		case err := <-errC:
+			assert.ErrorIs(t, err, myErr)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should disable retry when max attempts reached", func(t *testing.T) {
+		m := newModal(func(ctx context.Context, r *Reporter) error {
+			return myErr
+		}, WithRetry(RetryPolicy{MaxAttempts: 2}))
+		m.Start()
+		waitForErrorView(t, m)
+		assert.False(t, m.retry.retryButton.Disabled())
+		test.Tap(m.retry.retryButton)
+		waitForErrorView(t, m)
+		assert.True(t, m.retry.retryButton.Disabled())
+		assert.Equal(t, "Attempt 2 of 2 failed: failed", m.retry.errorLabel.Text)
+		test.Tap(m.retry.closeButton)
+		assert.ErrorIs(t, m.Wait(), myErr)
+	})
+	t.Run("should wait for backoff before retrying", func(t *testing.T) {
+		c := newFakeClock()
+		var attempts int32
+		m := newModal(func(ctx context.Context, r *Reporter) error {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				return myErr
+			}
+			return nil
+		}, withClock(c), WithRetry(RetryPolicy{Backoff: func(int) time.Duration {
+			return time.Second
+		}}))
+		m.Start()
+		waitForErrorView(t, m)
+		test.Tap(m.retry.retryButton)
+		assert.Eventually(t, func() bool {
+			return c.Pending() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+		c.Advance(time.Second)
+		assert.NoError(t, m.Wait())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+	t.Run("should not retry canceled actions", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		m := newModal(func(ctx context.Context, r *Reporter) error {
+			return ctx.Err()
+		}, WithContext(ctx), WithRetry(RetryPolicy{}))
+		m.Start()
+		assert.ErrorIs(t, m.Wait(), context.Canceled)
+		assert.False(t, m.errorView.Visible())
+	})
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	f := ExponentialBackoff(time.Second, 5*time.Second)
+	assert.Equal(t, time.Second, f(1))
+	assert.Equal(t, 2*time.Second, f(2))
+	assert.Equal(t, 4*time.Second, f(3))
+	assert.Equal(t, 5*time.Second, f(4))
+	assert.Equal(t, 5*time.Second, f(10))
+}
@@ -0,0 +1,297 @@
+package modal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Task is a named action run by a modal created with [NewTasks].
+type Task struct {
+	// Name of the task shown to the user.
+	Name string
+
+	// Action is the function run by the task.
+	// It reports its progress through r, which is shown in the task's row.
+	Action func(ctx context.Context, r *Reporter) error
+
+	// Maximum value of the task's progress indicator. The default is 1.
+	Max float64
+}
+
+// TaskError is the error of a failed task.
+type TaskError struct {
+	Task string
+	Err  error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Task, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// TaskErrors is the error passed to OnError when one or more tasks failed.
+type TaskErrors []*TaskError
+
+func (e TaskErrors) Error() string {
+	s := make([]string, len(e))
+	for i, x := range e {
+		s[i] = x.Error()
+	}
+	return fmt.Sprintf("%d of the tasks failed: %s", len(e), strings.Join(s, "; "))
+}
+
+// Is reports whether any of the task errors matches target.
+func (e TaskErrors) Is(target error) bool {
+	for _, x := range e {
+		if errors.Is(x, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithConcurrency sets the maximum number of tasks running at the same time
+// in a modal created with [NewTasks]. The default is to run all tasks at the same time.
+func WithConcurrency(n int) Option {
+	return func(m *Progress) {
+		m.concurrency = n
+	}
+}
+
+// NewTasks returns a new [Progress] modal, which runs several tasks.
+// The modal shows a row with a progress indicator for each task and an overall progress indicator.
+//
+// When one or more tasks fail, the other tasks continue to run
+// and OnError is called with a [TaskErrors] after all tasks have finished.
+// When the modal is canceled, tasks that have not yet started are not run
+// and are reported as failed with the error of the context.
+func NewTasks(title, message string, tasks []Task, parent fyne.Window, options ...Option) *Progress {
+	rows := make([]*taskRow, len(tasks))
+	box := container.NewVBox()
+	for i, t := range tasks {
+		rows[i] = newTaskRow(t)
+		box.Add(rows[i].content)
+	}
+	scroll := container.NewVScroll(box)
+	h := box.MinSize().Height
+	if h > taskListMaxHeight {
+		h = taskListMaxHeight
+	}
+	scroll.SetMinSize(fyne.NewSize(box.MinSize().Width, h))
+	var m *Progress
+	action := func(ctx context.Context, _ *Reporter) error {
+		return runTasks(ctx, tasks, rows, m.concurrency)
+	}
+	options = append(options, WithRange(0, float64(len(tasks))), withBody(scroll))
+	m = New(title, message, action, parent, options...)
+	for _, r := range rows {
+		r.onChanged = func() {
+			var total float64
+			for _, r := range rows {
+				total += r.fraction()
+			}
+			m.setValue(total)
+		}
+	}
+	return m
+}
+
+const taskListMaxHeight = 300
+
+func runTasks(ctx context.Context, tasks []Task, rows []*taskRow, concurrency int) error {
+	if concurrency <= 0 {
+		concurrency = len(tasks)
+	}
+	// Updates of the rows are serialized, because tasks run concurrently.
+	mu := new(sync.Mutex)
+	do := func(f func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		fyne.Do(f)
+	}
+	do(func() {
+		for _, r := range rows {
+			r.reset()
+		}
+	})
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i := range tasks {
+		i := i
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			do(func() {
+				rows[i].finish(err)
+			})
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			do(rows[i].start)
+			err := tasks[i].Action(ctx, &Reporter{t: rows[i], mu: mu})
+			errs[i] = err
+			do(func() {
+				rows[i].finish(err)
+			})
+		}()
+	}
+	wg.Wait()
+	var taskErrs TaskErrors
+	for i, err := range errs {
+		if err != nil {
+			taskErrs = append(taskErrs, &TaskError{Task: tasks[i].Name, Err: err})
+		}
+	}
+	if len(taskErrs) > 0 {
+		return taskErrs
+	}
+	return nil
+}
+
+// taskRow shows the state and progress of a task.
+type taskRow struct {
+	content   fyne.CanvasObject
+	detail    *widget.Label
+	done      bool
+	max       float64
+	name      *widget.Label
+	onChanged func()
+	pb        *widget.ProgressBar
+	pbi       *widget.ProgressBarInfinite
+	pg        binding.Float
+	status    *widget.Label
+	task      string
+	value     float64
+}
+
+func newTaskRow(t Task) *taskRow {
+	r := &taskRow{
+		detail: widget.NewLabel(""),
+		max:    t.Max,
+		name:   widget.NewLabel(t.Name),
+		pg:     binding.NewFloat(),
+		pbi:    widget.NewProgressBarInfinite(),
+		status: widget.NewLabel("Waiting"),
+		task:   t.Name,
+	}
+	if r.max <= 0 {
+		r.max = 1
+	}
+	r.pb = widget.NewProgressBarWithData(r.pg)
+	r.pb.Max = r.max
+	r.pbi.Hide()
+	r.detail.Importance = widget.LowImportance
+	r.detail.Truncation = fyne.TextTruncateEllipsis
+	r.detail.Hide()
+	r.status.Importance = widget.LowImportance
+	r.content = container.NewVBox(
+		container.NewBorder(nil, nil, r.name, r.status, container.NewStack(r.pb, r.pbi)),
+		r.detail,
+	)
+	return r
+}
+
+// fraction returns the completed fraction of the task.
+func (r *taskRow) fraction() float64 {
+	if r.done {
+		return 1
+	}
+	f := r.value / r.max
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// reset resets the row to its initial state before a run.
+func (r *taskRow) reset() {
+	r.done = false
+	r.value = 0
+	r.pg.Set(0)
+	r.name.SetText(r.task)
+	r.setDetail("")
+	r.setInfinite(false)
+	r.status.Importance = widget.LowImportance
+	r.status.SetText("Waiting")
+	r.changed()
+}
+
+func (r *taskRow) start() {
+	r.status.Importance = widget.MediumImportance
+	r.status.SetText("Running")
+}
+
+func (r *taskRow) finish(err error) {
+	r.done = true
+	r.setInfinite(false)
+	if errors.Is(err, context.Canceled) {
+		r.status.Importance = widget.WarningImportance
+		r.status.SetText("Canceled")
+	} else if err != nil {
+		r.status.Importance = widget.DangerImportance
+		r.status.SetText("Failed")
+	} else {
+		r.pg.Set(r.max)
+		r.status.Importance = widget.SuccessImportance
+		r.status.SetText("Done")
+	}
+	r.changed()
+}
+
+func (r *taskRow) changed() {
+	if r.onChanged != nil {
+		r.onChanged()
+	}
+}
+
+func (r *taskRow) setDetail(text string) {
+	r.detail.SetText(text)
+	if text == "" {
+		r.detail.Hide()
+	} else {
+		r.detail.Show()
+	}
+}
+
+func (r *taskRow) setInfinite(infinite bool) {
+	if infinite {
+		r.pb.Hide()
+		r.pbi.Show()
+	} else {
+		r.pbi.Hide()
+		r.pb.Show()
+	}
+}
+
+func (r *taskRow) setMessage(text string) {
+	r.name.SetText(text)
+}
+
+func (r *taskRow) setValue(v float64) {
+	r.value = v
+	r.pg.Set(v)
+	r.changed()
+}
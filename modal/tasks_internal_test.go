@@ -0,0 +1,114 @@
+package modal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRow(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should report fraction of progress", func(t *testing.T) {
+		r := newTaskRow(Task{Name: "a", Max: 4})
+		assert.Equal(t, 0.0, r.fraction())
+		r.setValue(1)
+		assert.Equal(t, 0.25, r.fraction())
+		r.setValue(8)
+		assert.Equal(t, 1.0, r.fraction())
+	})
+	t.Run("should report full fraction when finished", func(t *testing.T) {
+		r := newTaskRow(Task{Name: "a"})
+		r.finish(errors.New("failed"))
+		assert.Equal(t, 1.0, r.fraction())
+		assert.Equal(t, "Failed", r.status.Text)
+		assert.Equal(t, widget.DangerImportance, r.status.Importance)
+	})
+	t.Run("should show canceled tasks", func(t *testing.T) {
+		r := newTaskRow(Task{Name: "a"})
+		r.finish(context.Canceled)
+		assert.Equal(t, "Canceled", r.status.Text)
+	})
+	t.Run("can reset finished task", func(t *testing.T) {
+		r := newTaskRow(Task{Name: "a"})
+		r.setMessage("b")
+		r.finish(errors.New("failed"))
+		r.reset()
+		assert.Equal(t, 0.0, r.fraction())
+		assert.Equal(t, "Waiting", r.status.Text)
+		assert.Equal(t, widget.LowImportance, r.status.Importance)
+		assert.Equal(t, "a", r.name.Text)
+	})
+	t.Run("should show succeeded tasks", func(t *testing.T) {
+		r := newTaskRow(Task{Name: "a"})
+		r.start()
+		assert.Equal(t, "Running", r.status.Text)
+		r.finish(nil)
+		assert.Equal(t, "Done", r.status.Text)
+	})
+}
+
+func TestNewTasksOverallProgress(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	tasks := []Task{
+		{Name: "a", Max: 10, Action: func(ctx context.Context, r *Reporter) error {
+			r.SetValue(5)
+			return nil
+		}},
+		{Name: "b", Action: func(ctx context.Context, r *Reporter) error {
+			return nil
+		}},
+	}
+	m := NewTasks("Title", "Message", tasks, w)
+	assert.Equal(t, 2.0, m.pb.Max)
+	m.Start()
+	select {
+	case <-m.Done():
+		v, err := m.pg.Get()
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2.0, v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestNewTasksRestart(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var runs int
+	tasks := []Task{
+		{Name: "a", Action: func(ctx context.Context, r *Reporter) error {
+			runs++
+			if runs == 2 {
+				close(started)
+				<-release
+			}
+			return nil
+		}},
+	}
+	m := NewTasks("Title", "Message", tasks, w)
+	m.Start()
+	<-m.Done()
+	v, err := m.pg.Get()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1.0, v)
+	}
+	m.Start()
+	<-started
+	v, err = m.pg.Get()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0.0, v)
+	}
+	close(release)
+	<-m.Done()
+}
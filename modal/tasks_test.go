@@ -0,0 +1,125 @@
+package modal_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	kxmodal "github.com/ErikKalkoken/fyne-kx/modal"
+)
+
+func TestNewTasks(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	t.Run("should run all tasks and call OnSuccess", func(t *testing.T) {
+		var mu sync.Mutex
+		var ran []string
+		makeTask := func(name string) kxmodal.Task {
+			return kxmodal.Task{Name: name, Action: func(ctx context.Context, r *kxmodal.Reporter) error {
+				r.SetValue(1)
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, name)
+				return nil
+			}}
+		}
+		var succeeded bool
+		m := kxmodal.NewTasks("Title", "Message", []kxmodal.Task{makeTask("a"), makeTask("b"), makeTask("c")}, w)
+		m.OnSuccess = func() {
+			succeeded = true
+		}
+		m.Start()
+		select {
+		case <-m.Done():
+			assert.NoError(t, m.Result())
+			assert.True(t, succeeded)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.ElementsMatch(t, []string{"a", "b", "c"}, ran)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should report failed tasks in aggregated error", func(t *testing.T) {
+		myErr := errors.New("failed")
+		tasks := []kxmodal.Task{
+			{Name: "a", Action: func(ctx context.Context, r *kxmodal.Reporter) error {
+				return nil
+			}},
+			{Name: "b", Action: func(ctx context.Context, r *kxmodal.Reporter) error {
+				return myErr
+			}},
+		}
+		m := kxmodal.NewTasks("Title", "Message", tasks, w)
+		m.Start()
+		select {
+		case <-m.Done():
+			err := m.Result()
+			assert.ErrorIs(t, err, myErr)
+			var taskErrs kxmodal.TaskErrors
+			if assert.ErrorAs(t, err, &taskErrs) {
+				assert.Len(t, taskErrs, 1)
+				assert.Equal(t, "b", taskErrs[0].Task)
+			}
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should limit number of concurrent tasks", func(t *testing.T) {
+		var mu sync.Mutex
+		var running, maxRunning int
+		task := kxmodal.Task{Name: "x", Action: func(ctx context.Context, r *kxmodal.Reporter) error {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		}}
+		m := kxmodal.NewTasks("Title", "Message", []kxmodal.Task{task, task, task, task}, w, kxmodal.WithConcurrency(2))
+		m.Start()
+		select {
+		case <-m.Done():
+			assert.NoError(t, m.Result())
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, 2, maxRunning)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should not start tasks when canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var mu sync.Mutex
+		var count int
+		task := kxmodal.Task{Name: "x", Action: func(ctx context.Context, r *kxmodal.Reporter) error {
+			mu.Lock()
+			defer mu.Unlock()
+			count++
+			return nil
+		}}
+		m := kxmodal.NewTasks("Title", "Message", []kxmodal.Task{task, task}, w, kxmodal.WithContext(ctx))
+		m.Start()
+		select {
+		case <-m.Done():
+			assert.ErrorIs(t, m.Result(), context.Canceled)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, 0, count)
+		case <-time.After(timeout):
+			t.Fatal("timeout")
+		}
+	})
+}
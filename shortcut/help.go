@@ -0,0 +1,47 @@
+package shortcut
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// NewHelpDialog returns a dialog listing all registered shortcuts of the window, grouped by scope.
+func (r *Registry) NewHelpDialog() *dialog.CustomDialog {
+	list := container.NewVBox()
+	var group *fyne.Container
+	scope := "\x00" // never a valid scope, so the first shortcut always starts a new group
+	shortcuts := r.Shortcuts()
+	for _, s := range shortcuts {
+		if s.Scope != scope {
+			scope = s.Scope
+			title := scope
+			if title == "" {
+				title = "Global"
+			}
+			heading := widget.NewLabel(title)
+			heading.TextStyle.Bold = true
+			group = container.New(layout.NewFormLayout())
+			list.Add(heading)
+			list.Add(group)
+		}
+		key := widget.NewLabel(s.Label())
+		key.TextStyle.Monospace = true
+		group.Add(key)
+		group.Add(widget.NewLabel(s.Description))
+	}
+	if len(shortcuts) == 0 {
+		list.Add(widget.NewLabel("No shortcuts defined"))
+	}
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+	d := dialog.NewCustom("Keyboard shortcuts", "Close", scroll, r.w)
+	return d
+}
+
+// ShowHelp shows a dialog listing all registered shortcuts of the window.
+func (r *Registry) ShowHelp() {
+	r.NewHelpDialog().Show()
+}
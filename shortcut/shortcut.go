@@ -0,0 +1,264 @@
+/*
+Package shortcut provides a registry for the keyboard shortcuts of a window.
+
+All shortcuts of a window should be registered with its [Registry],
+so the app can tell which shortcuts exist and show them to the user in a help dialog:
+
+	r := kxshortcut.ForWindow(w)
+	err := r.Register(kxshortcut.Shortcut{
+		Shortcut:    &desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierShortcutDefault},
+		Description: "Save file",
+		Action:      saveFile,
+	})
+	if err != nil {
+		log.Fatal(err) // e.g. the shortcut is already registered
+	}
+	r.Register(kxshortcut.Shortcut{
+		Shortcut:    &desktop.CustomShortcut{KeyName: fyne.KeyF1},
+		Description: "Show keyboard shortcuts",
+		Action:      r.ShowHelp,
+	})
+
+# Scopes
+
+Shortcuts can be limited to a scope, e.g. a view or a tab.
+A scoped shortcut is only active while its scope is the current scope of the registry.
+Shortcuts without a scope are global and always active.
+
+The same key combination can be registered in several scopes,
+but not in a scope and globally.
+
+[Registry.BindAppTabs] keeps the current scope in sync with the selected tab of an [container.AppTabs].
+*/
+package shortcut
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// ErrConflict is returned when a key combination is already registered in an overlapping scope.
+var ErrConflict = errors.New("shortcut conflict")
+
+// Shortcut is a keyboard shortcut which can be registered with a [Registry].
+type Shortcut struct {
+	// The key combination, e.g. a [desktop.CustomShortcut].
+	Shortcut fyne.Shortcut
+
+	// Description of the shortcut shown in the help dialog.
+	Description string
+
+	// Scope limits the shortcut to a view or tab. Global shortcuts have an empty scope.
+	Scope string
+
+	// Action is called when the user presses the key combination.
+	Action func()
+}
+
+// Label returns a human readable label for the key combination of the shortcut, e.g. "Ctrl+S".
+func (s Shortcut) Label() string {
+	return Label(s.Shortcut)
+}
+
+// Label returns a human readable label for a key combination, e.g. "Ctrl+S".
+func Label(s fyne.Shortcut) string {
+	ks, ok := s.(fyne.KeyboardShortcut)
+	if !ok {
+		return s.ShortcutName()
+	}
+	var parts []string
+	mod := ks.Mod()
+	if mod&fyne.KeyModifierControl != 0 {
+		parts = append(parts, "Ctrl")
+	}
+	if mod&fyne.KeyModifierAlt != 0 {
+		parts = append(parts, "Alt")
+	}
+	if mod&fyne.KeyModifierShift != 0 {
+		parts = append(parts, "Shift")
+	}
+	if mod&fyne.KeyModifierSuper != 0 {
+		if runtime.GOOS == "darwin" {
+			parts = append(parts, "Cmd")
+		} else {
+			parts = append(parts, "Super")
+		}
+	}
+	parts = append(parts, string(ks.Key()))
+	return strings.Join(parts, "+")
+}
+
+// Registry keeps track of the keyboard shortcuts of a window.
+// All methods are safe to call concurrently.
+type Registry struct {
+	mu        sync.Mutex
+	scope     string
+	shortcuts map[string][]*Shortcut // keyed by shortcut name
+	w         fyne.Window
+}
+
+var registries = struct {
+	mu      sync.Mutex
+	windows map[fyne.Window]*Registry
+}{
+	windows: make(map[fyne.Window]*Registry),
+}
+
+// ForWindow returns the shortcut registry of window w.
+// The registry is created on first use.
+//
+// The registry is kept until it is released with [Registry.Release].
+// Apps which close windows other than the main window should release their registries,
+// e.g. from the OnClosed callback of the window.
+func ForWindow(w fyne.Window) *Registry {
+	registries.mu.Lock()
+	defer registries.mu.Unlock()
+	r, ok := registries.windows[w]
+	if !ok {
+		r = &Registry{
+			shortcuts: make(map[string][]*Shortcut),
+			w:         w,
+		}
+		registries.windows[w] = r
+	}
+	return r
+}
+
+// Release removes the registry of a window, which is closed or about to be closed,
+// so the window can be garbage collected.
+// Calling [ForWindow] again for the window returns a new registry.
+func (r *Registry) Release() {
+	registries.mu.Lock()
+	defer registries.mu.Unlock()
+	if registries.windows[r.w] == r {
+		delete(registries.windows, r.w)
+	}
+}
+
+// Register adds a shortcut to the window.
+// It returns an error matching [ErrConflict] when the key combination
+// is already registered in the same scope or when one of the two shortcuts is global.
+func (r *Registry) Register(s Shortcut) error {
+	if s.Shortcut == nil {
+		return errors.New("shortcut: key combination missing")
+	}
+	if s.Action == nil {
+		return fmt.Errorf("shortcut: action missing for %s", s.Label())
+	}
+	name := s.Shortcut.ShortcutName()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, x := range r.shortcuts[name] {
+		if x.Scope == "" || s.Scope == "" || x.Scope == s.Scope {
+			return fmt.Errorf("%w: %s is already used for %q", ErrConflict, s.Label(), x.Description)
+		}
+	}
+	if len(r.shortcuts[name]) == 0 {
+		r.w.Canvas().AddShortcut(s.Shortcut, func(fyne.Shortcut) {
+			r.trigger(name)
+		})
+	}
+	r.shortcuts[name] = append(r.shortcuts[name], &s)
+	return nil
+}
+
+// Unregister removes the shortcut with key combination sc from scope
+// and reports whether it was found.
+func (r *Registry) Unregister(sc fyne.Shortcut, scope string) bool {
+	name := sc.ShortcutName()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shortcuts := r.shortcuts[name]
+	for i, x := range shortcuts {
+		if x.Scope != scope {
+			continue
+		}
+		shortcuts = append(shortcuts[:i], shortcuts[i+1:]...)
+		if len(shortcuts) == 0 {
+			delete(r.shortcuts, name)
+			r.w.Canvas().RemoveShortcut(sc)
+		} else {
+			r.shortcuts[name] = shortcuts
+		}
+		return true
+	}
+	return false
+}
+
+// Scope returns the current scope.
+func (r *Registry) Scope() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.scope
+}
+
+// SetScope sets the current scope, which activates the shortcuts of that scope
+// and deactivates the shortcuts of all other scopes.
+// An empty scope leaves only the global shortcuts active.
+func (r *Registry) SetScope(scope string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scope = scope
+}
+
+// BindAppTabs sets the current scope to the text of the selected tab of tabs
+// whenever the selection changes. An existing OnSelected callback is preserved.
+func (r *Registry) BindAppTabs(tabs *container.AppTabs) {
+	if t := tabs.Selected(); t != nil {
+		r.SetScope(t.Text)
+	}
+	onSelected := tabs.OnSelected
+	tabs.OnSelected = func(t *container.TabItem) {
+		r.SetScope(t.Text)
+		if onSelected != nil {
+			onSelected(t)
+		}
+	}
+}
+
+// Shortcuts returns all registered shortcuts.
+// Global shortcuts come first, followed by the shortcuts of each scope.
+// Shortcuts of the same scope are sorted by their description.
+func (r *Registry) Shortcuts() []Shortcut {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := make([]Shortcut, 0)
+	for _, shortcuts := range r.shortcuts {
+		for _, x := range shortcuts {
+			s = append(s, *x)
+		}
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].Scope != s[j].Scope {
+			return s[i].Scope < s[j].Scope
+		}
+		if s[i].Description != s[j].Description {
+			return s[i].Description < s[j].Description
+		}
+		return s[i].Label() < s[j].Label()
+	})
+	return s
+}
+
+// trigger runs the action of the active shortcut with the given name, if any.
+func (r *Registry) trigger(name string) {
+	r.mu.Lock()
+	var action func()
+	for _, x := range r.shortcuts[name] {
+		if x.Scope == "" || x.Scope == r.scope {
+			action = x.Action
+			break
+		}
+	}
+	r.mu.Unlock()
+	if action != nil {
+		action()
+	}
+}
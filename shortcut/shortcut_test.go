@@ -0,0 +1,168 @@
+package shortcut_test
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+
+	kxshortcut "github.com/ErikKalkoken/fyne-kx/shortcut"
+)
+
+func typeShortcut(w fyne.Window, s fyne.Shortcut) {
+	w.Canvas().(fyne.Shortcutable).TypedShortcut(s)
+}
+
+func TestRegistry(t *testing.T) {
+	test.NewTempApp(t)
+	ctrlS := &desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierControl}
+	t.Run("should return same registry for a window", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		assert.Same(t, kxshortcut.ForWindow(w), kxshortcut.ForWindow(w))
+	})
+	t.Run("should return new registry after release", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		r.Release()
+		assert.NotSame(t, r, kxshortcut.ForWindow(w))
+	})
+	t.Run("should keep OnClosed callback of window", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		var closed bool
+		w.SetOnClosed(func() {
+			closed = true
+		})
+		kxshortcut.ForWindow(w)
+		w.Close()
+		assert.True(t, closed)
+	})
+	t.Run("should run action of registered shortcut", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		var called bool
+		err := r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Description: "Save", Action: func() {
+			called = true
+		}})
+		if assert.NoError(t, err) {
+			typeShortcut(w, ctrlS)
+			assert.True(t, called)
+		}
+	})
+	t.Run("should report conflicts", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		f := func() {}
+		err := r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Scope: "A", Action: f})
+		if assert.NoError(t, err) {
+			err = r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Scope: "A", Action: f})
+			assert.ErrorIs(t, err, kxshortcut.ErrConflict)
+			err = r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Action: f})
+			assert.ErrorIs(t, err, kxshortcut.ErrConflict)
+			err = r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Scope: "B", Action: f})
+			assert.NoError(t, err)
+		}
+	})
+	t.Run("should report missing action", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		err := kxshortcut.ForWindow(w).Register(kxshortcut.Shortcut{Shortcut: ctrlS})
+		assert.Error(t, err)
+	})
+	t.Run("should run only shortcuts of current scope", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		var called string
+		r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Scope: "A", Action: func() {
+			called = "A"
+		}})
+		r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Scope: "B", Action: func() {
+			called = "B"
+		}})
+		typeShortcut(w, ctrlS)
+		assert.Equal(t, "", called)
+		r.SetScope("B")
+		typeShortcut(w, ctrlS)
+		assert.Equal(t, "B", called)
+		r.SetScope("A")
+		typeShortcut(w, ctrlS)
+		assert.Equal(t, "A", called)
+	})
+	t.Run("can unregister shortcuts", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		var called bool
+		r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Action: func() {
+			called = true
+		}})
+		assert.True(t, r.Unregister(ctrlS, ""))
+		assert.False(t, r.Unregister(ctrlS, ""))
+		typeShortcut(w, ctrlS)
+		assert.False(t, called)
+		assert.Len(t, r.Shortcuts(), 0)
+	})
+	t.Run("should list shortcuts with global shortcuts first", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		f := func() {}
+		r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Scope: "Editor", Description: "Save", Action: f})
+		r.Register(kxshortcut.Shortcut{Shortcut: &desktop.CustomShortcut{KeyName: fyne.KeyF1}, Description: "Help", Action: f})
+		var got []string
+		for _, s := range r.Shortcuts() {
+			got = append(got, s.Label()+" "+s.Description)
+		}
+		assert.Equal(t, []string{"F1 Help", "Ctrl+S Save"}, got)
+	})
+	t.Run("should set scope from selected tab", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		var selected string
+		tabs := container.NewAppTabs(
+			container.NewTabItem("First", widget.NewLabel("First")),
+			container.NewTabItem("Second", widget.NewLabel("Second")),
+		)
+		tabs.OnSelected = func(ti *container.TabItem) {
+			selected = ti.Text
+		}
+		r.BindAppTabs(tabs)
+		assert.Equal(t, "First", r.Scope())
+		tabs.SelectIndex(1)
+		assert.Equal(t, "Second", r.Scope())
+		assert.Equal(t, "Second", selected)
+	})
+	t.Run("can show help dialog", func(t *testing.T) {
+		w := test.NewWindow(nil)
+		defer w.Close()
+		r := kxshortcut.ForWindow(w)
+		r.Register(kxshortcut.Shortcut{Shortcut: ctrlS, Description: "Save", Action: func() {}})
+		r.ShowHelp()
+		assert.NotNil(t, w.Canvas().Overlays().Top())
+	})
+}
+
+func TestLabel(t *testing.T) {
+	cases := []struct {
+		shortcut fyne.Shortcut
+		want     string
+	}{
+		{&desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierControl}, "Ctrl+S"},
+		{&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift}, "Ctrl+Shift+Z"},
+		{&desktop.CustomShortcut{KeyName: fyne.KeyF1}, "F1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, kxshortcut.Label(tc.shortcut))
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package theme
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// highContrastLight are the colors of the light high contrast theme.
+var highContrastLight = map[fyne.ThemeColorName]color.Color{
+	theme.ColorNameBackground:          color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameButton:              color.NRGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff},
+	theme.ColorNameDisabled:            color.NRGBA{R: 0x76, G: 0x76, B: 0x76, A: 0xff},
+	theme.ColorNameDisabledButton:      color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff},
+	theme.ColorNameError:               color.NRGBA{R: 0xb0, G: 0x00, B: 0x20, A: 0xff},
+	theme.ColorNameFocus:               color.NRGBA{R: 0x00, G: 0x40, B: 0xc0, A: 0xff},
+	theme.ColorNameForeground:          color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnError:   color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameForegroundOnPrimary: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameForegroundOnSuccess: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameForegroundOnWarning: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameHeaderBackground:    color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff},
+	theme.ColorNameHover:               color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x1f},
+	theme.ColorNameHyperlink:           color.NRGBA{R: 0x00, G: 0x00, B: 0xee, A: 0xff},
+	theme.ColorNameInputBackground:     color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameInputBorder:         color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameMenuBackground:      color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameOverlayBackground:   color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNamePlaceHolder:         color.NRGBA{R: 0x59, G: 0x59, B: 0x59, A: 0xff},
+	theme.ColorNamePressed:             color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x33},
+	theme.ColorNamePrimary:             color.NRGBA{R: 0x00, G: 0x40, B: 0xc0, A: 0xff},
+	theme.ColorNameScrollBar:           color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xcc},
+	theme.ColorNameScrollBarBackground: color.NRGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff},
+	theme.ColorNameSelection:           color.NRGBA{R: 0x00, G: 0x40, B: 0xc0, A: 0x40},
+	theme.ColorNameSeparator:           color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameShadow:              color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x66},
+	theme.ColorNameSuccess:             color.NRGBA{R: 0x00, G: 0x5e, B: 0x1b, A: 0xff},
+	theme.ColorNameWarning:             color.NRGBA{R: 0x7a, G: 0x42, B: 0x00, A: 0xff},
+}
+
+// highContrastDark are the colors of the dark high contrast theme.
+var highContrastDark = map[fyne.ThemeColorName]color.Color{
+	theme.ColorNameBackground:          color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameButton:              color.NRGBA{R: 0x26, G: 0x26, B: 0x26, A: 0xff},
+	theme.ColorNameDisabled:            color.NRGBA{R: 0x8c, G: 0x8c, B: 0x8c, A: 0xff},
+	theme.ColorNameDisabledButton:      color.NRGBA{R: 0x14, G: 0x14, B: 0x14, A: 0xff},
+	theme.ColorNameError:               color.NRGBA{R: 0xff, G: 0x6b, B: 0x6b, A: 0xff},
+	theme.ColorNameFocus:               color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff},
+	theme.ColorNameForeground:          color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameForegroundOnError:   color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnPrimary: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnSuccess: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnWarning: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameHeaderBackground:    color.NRGBA{R: 0x14, G: 0x14, B: 0x14, A: 0xff},
+	theme.ColorNameHover:               color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x29},
+	theme.ColorNameHyperlink:           color.NRGBA{R: 0x8a, G: 0xb4, B: 0xff, A: 0xff},
+	theme.ColorNameInputBackground:     color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameInputBorder:         color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameMenuBackground:      color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameOverlayBackground:   color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNamePlaceHolder:         color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xff},
+	theme.ColorNamePressed:             color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x3d},
+	theme.ColorNamePrimary:             color.NRGBA{R: 0x8a, G: 0xb4, B: 0xff, A: 0xff},
+	theme.ColorNameScrollBar:           color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xcc},
+	theme.ColorNameScrollBarBackground: color.NRGBA{R: 0x26, G: 0x26, B: 0x26, A: 0xff},
+	theme.ColorNameSelection:           color.NRGBA{R: 0x8a, G: 0xb4, B: 0xff, A: 0x66},
+	theme.ColorNameSeparator:           color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameShadow:              color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xcc},
+	theme.ColorNameSuccess:             color.NRGBA{R: 0x5f, G: 0xd3, B: 0x5f, A: 0xff},
+	theme.ColorNameWarning:             color.NRGBA{R: 0xff, G: 0xb0, B: 0x00, A: 0xff},
+}
+
+// HighContrast returns a theme with maximum contrast between text and backgrounds
+// and solid borders and separators, which meets WCAG 2.1 level AAA for text.
+// The theme has a fixed variant.
+//
+// For example here is how to set an app to the dark high contrast theme:
+//
+//	app.Settings().SetTheme(kxtheme.HighContrast(theme.VariantDark))
+func HighContrast(v fyne.ThemeVariant) fyne.Theme {
+	colors := highContrastLight
+	if v == theme.VariantDark {
+		colors = highContrastDark
+	}
+	b := NewBuilder(theme.DefaultTheme()).FixedVariant(v)
+	for name, c := range colors {
+		b.Color(name, c)
+	}
+	b.Size(theme.SizeNameInputBorder, 2)
+	b.Size(theme.SizeNameSeparatorThickness, 2)
+	return b.Build()
+}
+
+// variantColors are colors for the light and the dark variant.
+type variantColors struct {
+	light, dark map[fyne.ThemeColorName]color.Color
+}
+
+// apply sets the colors in builder b.
+func (vc variantColors) apply(b *Builder) {
+	for name, c := range vc.light {
+		b.ColorForVariant(name, theme.VariantLight, c)
+	}
+	for name, c := range vc.dark {
+		b.ColorForVariant(name, theme.VariantDark, c)
+	}
+}
+
+// accessibleDefaultColors replace the colors of the default Fyne theme, which do not meet WCAG 2.1 level AA.
+// They stay close to the default colors: Primary is a slightly darker blue in the light variant
+// and a lighter blue with black text in the dark variant.
+var accessibleDefaultColors = variantColors{
+	light: map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameFocus:       color.NRGBA{R: 0x25, G: 0x63, B: 0xeb, A: 0xcc},
+		theme.ColorNameHyperlink:   color.NRGBA{R: 0x25, G: 0x63, B: 0xeb, A: 0xff},
+		theme.ColorNameInputBorder: color.NRGBA{R: 0x8a, G: 0x8a, B: 0x8a, A: 0xff},
+		theme.ColorNamePlaceHolder: color.NRGBA{R: 0x6b, G: 0x6b, B: 0x6b, A: 0xff},
+		theme.ColorNamePrimary:     color.NRGBA{R: 0x25, G: 0x63, B: 0xeb, A: 0xff},
+	},
+	dark: map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameFocus:               color.NRGBA{R: 0x6e, G: 0xa8, B: 0xfe, A: 0xcc},
+		theme.ColorNameForegroundOnPrimary: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+		theme.ColorNameHyperlink:           color.NRGBA{R: 0x6e, G: 0xa8, B: 0xfe, A: 0xff},
+		theme.ColorNameInputBorder:         color.NRGBA{R: 0x7a, G: 0x7a, B: 0x7a, A: 0xff},
+		theme.ColorNamePrimary:             color.NRGBA{R: 0x6e, G: 0xa8, B: 0xfe, A: 0xff},
+	},
+}
+
+// Status colors for people with deuteranopia (no green cones).
+// Red and green look alike, so success is a blue.
+// Error and warning differ mainly in lightness: In the light variant error is a dark crimson next to an amber warning
+// and in the dark variant error is a muted rose next to a bright yellow warning.
+var deuteranopiaColors = variantColors{
+	light: map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameError:   color.NRGBA{R: 0x7d, G: 0x04, B: 0x28, A: 0xff},
+		theme.ColorNameSuccess: color.NRGBA{R: 0x09, G: 0x69, B: 0xda, A: 0xff},
+		theme.ColorNameWarning: color.NRGBA{R: 0xb3, G: 0x59, B: 0x06, A: 0xff},
+	},
+	dark: map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameError:   color.NRGBA{R: 0xd5, G: 0x5f, B: 0x6d, A: 0xff},
+		theme.ColorNameSuccess: color.NRGBA{R: 0x58, G: 0xa6, B: 0xff, A: 0xff},
+		theme.ColorNameWarning: color.NRGBA{R: 0xff, G: 0xdd, B: 0x2c, A: 0xff},
+	},
+}
+
+// Status colors for people with protanopia (no red cones).
+// Red and green look alike and reds look darker, so success is a cyan blue.
+// In the light variant error is a very dark red next to an olive warning
+// and in the dark variant error is a pure red, which appears darker than the bright yellow warning.
+var protanopiaColors = variantColors{
+	light: map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameError:   color.NRGBA{R: 0x6b, G: 0x03, B: 0x0b, A: 0xff},
+		theme.ColorNameSuccess: color.NRGBA{R: 0x00, G: 0x6c, B: 0x8f, A: 0xff},
+		theme.ColorNameWarning: color.NRGBA{R: 0x84, G: 0x71, B: 0x0a, A: 0xff},
+	},
+	dark: map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameError:   color.NRGBA{R: 0xfa, G: 0x28, B: 0x27, A: 0xff},
+		theme.ColorNameSuccess: color.NRGBA{R: 0x4c, G: 0xd2, B: 0xf0, A: 0xff},
+		theme.ColorNameWarning: color.NRGBA{R: 0xff, G: 0xdd, B: 0x2c, A: 0xff},
+	},
+}
+
+// DeuteranopiaSafe returns the default Fyne theme with success, warning and error colors,
+// which can be distinguished by people with deuteranopia (red-green color blindness).
+// The colors of the default theme, which do not meet WCAG 2.1 level AA,
+// are replaced with similar colors, so that all checks of [CheckContrast] pass.
+// These are primary, hyperlink, placeholder, input border and focus.
+//
+// The theme follows the current os settings for the variant.
+// Use a [Builder] for fixing the variant or changing more colors.
+func DeuteranopiaSafe() fyne.Theme {
+	return colorBlindSafe(deuteranopiaColors)
+}
+
+// ProtanopiaSafe returns the default Fyne theme with success, warning and error colors,
+// which can be distinguished by people with protanopia (red-green color blindness).
+// The colors of the default theme, which do not meet WCAG 2.1 level AA,
+// are replaced with similar colors, so that all checks of [CheckContrast] pass.
+// These are primary, hyperlink, placeholder, input border and focus.
+//
+// The theme follows the current os settings for the variant.
+// Use a [Builder] for fixing the variant or changing more colors.
+func ProtanopiaSafe() fyne.Theme {
+	return colorBlindSafe(protanopiaColors)
+}
+
+// colorBlindSafe returns the default Fyne theme with accessible colors and the status colors sc.
+// Texts on status colors are white in the light and black in the dark variant.
+func colorBlindSafe(sc variantColors) fyne.Theme {
+	b := NewBuilder(theme.DefaultTheme())
+	accessibleDefaultColors.apply(b)
+	sc.apply(b)
+	for _, name := range []fyne.ThemeColorName{
+		theme.ColorNameForegroundOnError,
+		theme.ColorNameForegroundOnSuccess,
+		theme.ColorNameForegroundOnWarning,
+	} {
+		b.ColorForVariant(name, theme.VariantLight, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+		b.ColorForVariant(name, theme.VariantDark, color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff})
+	}
+	return b.Build()
+}
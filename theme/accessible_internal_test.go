@@ -0,0 +1,84 @@
+package theme
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+// Matrices for simulating dichromacy in linear RGB by Viénot, Brettel and Mollon (1999).
+var (
+	simulateDeuteranopia = [3][3]float64{
+		{0.29275, 0.70725, 0},
+		{0.29275, 0.70725, 0},
+		{-0.02234, 0.02234, 1},
+	}
+	simulateProtanopia = [3][3]float64{
+		{0.11238, 0.88762, 0},
+		{0.11238, 0.88762, 0},
+		{0.00401, -0.00401, 1},
+	}
+)
+
+// simulate returns c as seen by a person with the dichromacy of matrix m.
+func simulate(c color.Color, m [3][3]float64) color.NRGBA {
+	r, g, b := linearRGB(c)
+	channel := func(row [3]float64) uint8 {
+		v := row[0]*r + row[1]*g + row[2]*b
+		v = delinearize(math.Max(0, math.Min(1, v)))
+		return uint8(math.Round(v * 0xff))
+	}
+	return color.NRGBA{R: channel(m[0]), G: channel(m[1]), B: channel(m[2]), A: 0xff}
+}
+
+// deltaE returns the CIE76 color difference of two colors.
+func deltaE(c1, c2 color.Color) float64 {
+	lab := func(c color.Color) (l, a, b float64) {
+		x := toLCh(c)
+		rad := x.h * math.Pi / 180
+		return x.l, x.c * math.Cos(rad), x.c * math.Sin(rad)
+	}
+	l1, a1, b1 := lab(c1)
+	l2, a2, b2 := lab(c2)
+	return math.Sqrt((l1-l2)*(l1-l2) + (a1-a2)*(a1-a2) + (b1-b2)*(b1-b2))
+}
+
+// minStatusDifference is the minimum color difference of status colors as seen with color blindness.
+const minStatusDifference = 35.0
+
+func TestColorBlindSafe(t *testing.T) {
+	test.NewTempApp(t)
+	statusNames := []fyne.ThemeColorName{theme.ColorNameError, theme.ColorNameSuccess, theme.ColorNameWarning}
+	cases := []struct {
+		name     string
+		th       fyne.Theme
+		simulate [3][3]float64
+	}{
+		{"deuteranopia", DeuteranopiaSafe(), simulateDeuteranopia},
+		{"protanopia", ProtanopiaSafe(), simulateProtanopia},
+	}
+	for _, tc := range cases {
+		t.Run("status colors should be distinguishable with "+tc.name, func(t *testing.T) {
+			for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+				for i, n1 := range statusNames {
+					for _, n2 := range statusNames[i+1:] {
+						c1 := simulate(tc.th.Color(n1, v), tc.simulate)
+						c2 := simulate(tc.th.Color(n2, v), tc.simulate)
+						assert.Greater(t, deltaE(c1, c2), minStatusDifference, "%s and %s in variant %d", n1, n2, v)
+					}
+				}
+			}
+		})
+	}
+	t.Run("default status colors should be hard to distinguish with deuteranopia", func(t *testing.T) {
+		th := theme.DefaultTheme()
+		c1 := simulate(th.Color(theme.ColorNameError, theme.VariantLight), simulateDeuteranopia)
+		c2 := simulate(th.Color(theme.ColorNameSuccess, theme.VariantLight), simulateDeuteranopia)
+		assert.Less(t, deltaE(c1, c2), minStatusDifference)
+	})
+}
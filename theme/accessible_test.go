@@ -0,0 +1,63 @@
+package theme_test
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+
+	kxtheme "github.com/ErikKalkoken/fyne-kx/theme"
+)
+
+func TestHighContrast(t *testing.T) {
+	test.NewTempApp(t)
+	for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+		th := kxtheme.HighContrast(v)
+		name := "light"
+		if v == theme.VariantDark {
+			name = "dark"
+		}
+		t.Run("should pass all contrast checks for "+name, func(t *testing.T) {
+			r := kxtheme.CheckContrast(th)
+			assert.Empty(t, r.Failures())
+		})
+		t.Run("should meet level AAA for all text pairs for "+name, func(t *testing.T) {
+			r := kxtheme.CheckContrast(th)
+			for _, c := range r.Checks {
+				if c.Minimum == kxtheme.MinContrastText {
+					assert.GreaterOrEqual(t, c.Ratio, 7.0, c)
+				}
+			}
+		})
+		t.Run("should have fixed variant for "+name, func(t *testing.T) {
+			assert.Equal(t, th.Color(theme.ColorNameBackground, theme.VariantLight), th.Color(theme.ColorNameBackground, theme.VariantDark))
+		})
+	}
+	t.Run("should have different variants", func(t *testing.T) {
+		light := kxtheme.HighContrast(theme.VariantLight)
+		dark := kxtheme.HighContrast(theme.VariantDark)
+		assert.NotEqual(t, light.Color(theme.ColorNameBackground, theme.VariantLight), dark.Color(theme.ColorNameBackground, theme.VariantLight))
+	})
+}
+
+func TestColorBlindSafe(t *testing.T) {
+	test.NewTempApp(t)
+	for name, th := range map[string]fyne.Theme{
+		"deuteranopia": kxtheme.DeuteranopiaSafe(),
+		"protanopia":   kxtheme.ProtanopiaSafe(),
+	} {
+		t.Run("should pass all contrast checks for "+name, func(t *testing.T) {
+			r := kxtheme.CheckContrast(th)
+			assert.Empty(t, r.Failures())
+		})
+		t.Run("should keep other colors of default theme for "+name, func(t *testing.T) {
+			for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+				for _, n := range []fyne.ThemeColorName{theme.ColorNameBackground, theme.ColorNameForeground, theme.ColorNameButton} {
+					assert.Equal(t, theme.DefaultTheme().Color(n, v), th.Color(n, v), n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package theme
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Builder builds themes, which override individual colors, sizes, fonts and icons of a base theme.
+// Everything which is not overridden falls back to the base theme.
+//
+// For example here is how to create a theme with a custom primary color:
+//
+//	th := kxtheme.NewBuilder(theme.DefaultTheme()).
+//		Color(theme.ColorNamePrimary, color.NRGBA{R: 0x00, G: 0x96, B: 0x88, A: 0xff}).
+//		Build()
+//	app.Settings().SetTheme(th)
+type Builder struct {
+	base    fyne.Theme
+	colors  map[fyne.ThemeColorName]map[fyne.ThemeVariant]color.Color
+	fonts   map[fyne.TextStyle]fyne.Resource
+	icons   map[fyne.ThemeIconName]fyne.Resource
+	sizes   map[fyne.ThemeSizeName]float32
+	variant *fyne.ThemeVariant
+}
+
+// NewBuilder returns a new [Builder] for a theme based on base.
+// When base is nil the default Fyne theme is used.
+func NewBuilder(base fyne.Theme) *Builder {
+	if base == nil {
+		base = theme.DefaultTheme()
+	}
+	b := &Builder{
+		base:   base,
+		colors: make(map[fyne.ThemeColorName]map[fyne.ThemeVariant]color.Color),
+		fonts:  make(map[fyne.TextStyle]fyne.Resource),
+		icons:  make(map[fyne.ThemeIconName]fyne.Resource),
+		sizes:  make(map[fyne.ThemeSizeName]float32),
+	}
+	return b
+}
+
+// Color overrides a color for all variants.
+func (b *Builder) Color(name fyne.ThemeColorName, c color.Color) *Builder {
+	b.ColorForVariant(name, theme.VariantLight, c)
+	b.ColorForVariant(name, theme.VariantDark, c)
+	return b
+}
+
+// ColorForVariant overrides a color for one variant.
+func (b *Builder) ColorForVariant(name fyne.ThemeColorName, v fyne.ThemeVariant, c color.Color) *Builder {
+	if b.colors[name] == nil {
+		b.colors[name] = make(map[fyne.ThemeVariant]color.Color)
+	}
+	b.colors[name][v] = c
+	return b
+}
+
+// Font overrides the font for a text style.
+// Only the properties of a text style which select a font are considered,
+// i.e. Bold, Italic, Monospace and Symbol.
+func (b *Builder) Font(style fyne.TextStyle, r fyne.Resource) *Builder {
+	b.fonts[fontStyle(style)] = r
+	return b
+}
+
+// Icon overrides an icon.
+func (b *Builder) Icon(name fyne.ThemeIconName, r fyne.Resource) *Builder {
+	b.icons[name] = r
+	return b
+}
+
+// Size overrides a size.
+func (b *Builder) Size(name fyne.ThemeSizeName, s float32) *Builder {
+	b.sizes[name] = s
+	return b
+}
+
+// FixedVariant sets a fixed theme variant, which is used independent of the current os settings.
+func (b *Builder) FixedVariant(v fyne.ThemeVariant) *Builder {
+	b.variant = &v
+	return b
+}
+
+// Build returns a new theme with all overrides.
+// Changing the builder afterwards does not change themes which have already been built.
+func (b *Builder) Build() fyne.Theme {
+	th := &builtTheme{
+		base:   b.base,
+		colors: make(map[fyne.ThemeColorName]map[fyne.ThemeVariant]color.Color),
+		fonts:  make(map[fyne.TextStyle]fyne.Resource),
+		icons:  make(map[fyne.ThemeIconName]fyne.Resource),
+		sizes:  make(map[fyne.ThemeSizeName]float32),
+	}
+	for name, variants := range b.colors {
+		th.colors[name] = make(map[fyne.ThemeVariant]color.Color)
+		for v, c := range variants {
+			th.colors[name][v] = c
+		}
+	}
+	for k, v := range b.fonts {
+		th.fonts[k] = v
+	}
+	for k, v := range b.icons {
+		th.icons[k] = v
+	}
+	for k, v := range b.sizes {
+		th.sizes[k] = v
+	}
+	if b.variant != nil {
+		v := *b.variant
+		th.variant = &v
+	}
+	return th
+}
+
+// fontStyle returns a text style with only the properties which select a font.
+func fontStyle(style fyne.TextStyle) fyne.TextStyle {
+	return fyne.TextStyle{
+		Bold:      style.Bold,
+		Italic:    style.Italic,
+		Monospace: style.Monospace,
+		Symbol:    style.Symbol,
+	}
+}
+
+// builtTheme is a theme created by a [Builder].
+type builtTheme struct {
+	base    fyne.Theme
+	colors  map[fyne.ThemeColorName]map[fyne.ThemeVariant]color.Color
+	fonts   map[fyne.TextStyle]fyne.Resource
+	icons   map[fyne.ThemeIconName]fyne.Resource
+	sizes   map[fyne.ThemeSizeName]float32
+	variant *fyne.ThemeVariant
+}
+
+func (th *builtTheme) Color(n fyne.ThemeColorName, v fyne.ThemeVariant) color.Color {
+	if th.variant != nil {
+		v = *th.variant
+	}
+	if c, ok := th.colors[n][v]; ok {
+		return c
+	}
+	return th.base.Color(n, v)
+}
+
+func (th *builtTheme) Font(style fyne.TextStyle) fyne.Resource {
+	if r, ok := th.fonts[fontStyle(style)]; ok {
+		return r
+	}
+	return th.base.Font(style)
+}
+
+func (th *builtTheme) Icon(n fyne.ThemeIconName) fyne.Resource {
+	if r, ok := th.icons[n]; ok {
+		return r
+	}
+	return th.base.Icon(n)
+}
+
+func (th *builtTheme) Size(n fyne.ThemeSizeName) float32 {
+	if s, ok := th.sizes[n]; ok {
+		return s
+	}
+	return th.base.Size(n)
+}
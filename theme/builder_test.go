@@ -0,0 +1,75 @@
+package theme_test
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+
+	kxtheme "github.com/ErikKalkoken/fyne-kx/theme"
+)
+
+func TestBuilder(t *testing.T) {
+	test.NewTempApp(t)
+	base := theme.DefaultTheme()
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	t.Run("should fall back to base theme", func(t *testing.T) {
+		th := kxtheme.NewBuilder(base).Build()
+		for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+			assert.Equal(t, base.Color(theme.ColorNamePrimary, v), th.Color(theme.ColorNamePrimary, v))
+		}
+		assert.Equal(t, base.Size(theme.SizeNamePadding), th.Size(theme.SizeNamePadding))
+		assert.Equal(t, base.Font(fyne.TextStyle{Bold: true}), th.Font(fyne.TextStyle{Bold: true}))
+		assert.Equal(t, base.Icon(theme.IconNameHome), th.Icon(theme.IconNameHome))
+	})
+	t.Run("should use default theme when base is nil", func(t *testing.T) {
+		th := kxtheme.NewBuilder(nil).Build()
+		assert.Equal(t, base.Size(theme.SizeNamePadding), th.Size(theme.SizeNamePadding))
+	})
+	t.Run("can override color for all variants", func(t *testing.T) {
+		th := kxtheme.NewBuilder(base).Color(theme.ColorNamePrimary, red).Build()
+		assert.Equal(t, red, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+		assert.Equal(t, red, th.Color(theme.ColorNamePrimary, theme.VariantDark))
+	})
+	t.Run("can override color for one variant", func(t *testing.T) {
+		th := kxtheme.NewBuilder(base).
+			ColorForVariant(theme.ColorNamePrimary, theme.VariantDark, blue).
+			Build()
+		assert.Equal(t, base.Color(theme.ColorNamePrimary, theme.VariantLight), th.Color(theme.ColorNamePrimary, theme.VariantLight))
+		assert.Equal(t, blue, th.Color(theme.ColorNamePrimary, theme.VariantDark))
+	})
+	t.Run("can override size", func(t *testing.T) {
+		th := kxtheme.NewBuilder(base).Size(theme.SizeNameText, 20).Build()
+		assert.Equal(t, float32(20), th.Size(theme.SizeNameText))
+	})
+	t.Run("can override font for text style", func(t *testing.T) {
+		font := fyne.NewStaticResource("font.ttf", []byte("font"))
+		th := kxtheme.NewBuilder(base).Font(fyne.TextStyle{Bold: true}, font).Build()
+		assert.Equal(t, font, th.Font(fyne.TextStyle{Bold: true}))
+		assert.Equal(t, font, th.Font(fyne.TextStyle{Bold: true, Underline: true}))
+		assert.Equal(t, base.Font(fyne.TextStyle{}), th.Font(fyne.TextStyle{}))
+	})
+	t.Run("can override icon", func(t *testing.T) {
+		icon := fyne.NewStaticResource("icon.svg", []byte("<svg/>"))
+		th := kxtheme.NewBuilder(base).Icon(theme.IconNameHome, icon).Build()
+		assert.Equal(t, icon, th.Icon(theme.IconNameHome))
+	})
+	t.Run("can fix variant", func(t *testing.T) {
+		th := kxtheme.NewBuilder(base).
+			ColorForVariant(theme.ColorNamePrimary, theme.VariantDark, blue).
+			FixedVariant(theme.VariantDark).
+			Build()
+		assert.Equal(t, blue, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+		assert.Equal(t, base.Color(theme.ColorNameBackground, theme.VariantDark), th.Color(theme.ColorNameBackground, theme.VariantLight))
+	})
+	t.Run("should not change built themes", func(t *testing.T) {
+		b := kxtheme.NewBuilder(base).Color(theme.ColorNamePrimary, red)
+		th := b.Build()
+		b.Color(theme.ColorNamePrimary, blue)
+		assert.Equal(t, red, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+	})
+}
@@ -0,0 +1,122 @@
+package theme
+
+import (
+	"image/color"
+	"math"
+)
+
+// This file contains conversions between sRGB and the CIE L*a*b* color space (D65 white point).
+
+// lch is a color in the polar form of the CIE L*a*b* color space.
+type lch struct {
+	l float64 // lightness from 0 to 100
+	c float64 // chroma
+	h float64 // hue in degrees
+}
+
+const (
+	labEpsilon = 216.0 / 24389.0
+	labKappa   = 24389.0 / 27.0
+	whiteX     = 0.95047
+	whiteY     = 1.0
+	whiteZ     = 1.08883
+)
+
+// linearize returns the linear value of an sRGB channel value between 0 and 1.
+func linearize(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// delinearize returns the sRGB channel value of a linear value between 0 and 1.
+func delinearize(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// linearRGB returns the linear channel values of c between 0 and 1 ignoring alpha.
+func linearRGB(c color.Color) (r, g, b float64) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return linearize(float64(n.R) / 0xff), linearize(float64(n.G) / 0xff), linearize(float64(n.B) / 0xff)
+}
+
+// relativeLuminance returns the relative luminance of c as defined by WCAG, ignoring alpha.
+func relativeLuminance(c color.Color) float64 {
+	r, g, b := linearRGB(c)
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+func toLCh(c color.Color) lch {
+	r, g, b := linearRGB(c)
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / whiteX
+	y := (0.2126729*r + 0.7151522*g + 0.0721750*b) / whiteY
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / whiteZ
+	f := func(t float64) float64 {
+		if t > labEpsilon {
+			return math.Cbrt(t)
+		}
+		return (labKappa*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	l := 116*fy - 16
+	a := 500 * (fx - fy)
+	bb := 200 * (fy - fz)
+	h := math.Atan2(bb, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return lch{l: l, c: math.Hypot(a, bb), h: h}
+}
+
+// linear returns the linear RGB channel values of c, which can be outside of the sRGB gamut.
+func (c lch) linear() (r, g, b float64) {
+	rad := c.h * math.Pi / 180
+	a, bb := c.c*math.Cos(rad), c.c*math.Sin(rad)
+	fy := (c.l + 16) / 116
+	fx := fy + a/500
+	fz := fy - bb/200
+	finv := func(t float64) float64 {
+		if t3 := t * t * t; t3 > labEpsilon {
+			return t3
+		}
+		return (116*t - 16) / labKappa
+	}
+	var y float64
+	if c.l > labKappa*labEpsilon {
+		y = fy * fy * fy
+	} else {
+		y = c.l / labKappa
+	}
+	x, z := finv(fx)*whiteX, finv(fz)*whiteZ
+	y *= whiteY
+	r = 3.2404542*x - 1.5371385*y - 0.4985314*z
+	g = -0.9692660*x + 1.8760108*y + 0.0415560*z
+	b = 0.0556434*x - 0.2040259*y + 1.0572252*z
+	return r, g, b
+}
+
+// inGamut reports whether c can be displayed in sRGB.
+func (c lch) inGamut() bool {
+	const tolerance = 1e-4
+	r, g, b := c.linear()
+	for _, v := range []float64{r, g, b} {
+		if v < -tolerance || v > 1+tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// toNRGBA returns c as opaque sRGB color. Channel values outside of the gamut are clipped.
+func (c lch) toNRGBA() color.NRGBA {
+	r, g, b := c.linear()
+	channel := func(v float64) uint8 {
+		v = delinearize(math.Max(0, math.Min(1, v)))
+		return uint8(math.Round(v * 0xff))
+	}
+	return color.NRGBA{R: channel(r), G: channel(g), B: channel(b), A: 0xff}
+}
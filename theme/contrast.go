@@ -0,0 +1,158 @@
+package theme
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Minimum contrast ratios required by WCAG 2.1 level AA.
+const (
+	// MinContrastText is the minimum contrast ratio for text.
+	MinContrastText = 4.5
+
+	// MinContrastUI is the minimum contrast ratio for user interface components like input borders.
+	MinContrastUI = 3.0
+)
+
+// ContrastRatio returns the contrast ratio of a foreground and a background color as defined by WCAG 2.1.
+// The ratio ranges from 1 (no contrast) to 21 (black on white).
+// A translucent foreground color is blended onto the background color first.
+// The background color is treated as opaque.
+func ContrastRatio(fg, bg color.Color) float64 {
+	l1 := relativeLuminance(blend(fg, bg))
+	l2 := relativeLuminance(bg)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// blend returns the opaque color of fg drawn over the opaque color bg.
+func blend(fg, bg color.Color) color.Color {
+	f := color.NRGBAModel.Convert(fg).(color.NRGBA)
+	if f.A == 0xff {
+		return f
+	}
+	b := color.NRGBAModel.Convert(bg).(color.NRGBA)
+	a := float64(f.A) / 0xff
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x)*a + float64(y)*(1-a)))
+	}
+	return color.NRGBA{R: mix(f.R, b.R), G: mix(f.G, b.G), B: mix(f.B, b.B), A: 0xff}
+}
+
+// ContrastCheck is the result of checking the contrast of a pair of theme colors,
+// which Fyne renders on top of each other.
+type ContrastCheck struct {
+	// The theme variant which was checked.
+	Variant fyne.ThemeVariant
+
+	// Name of the foreground color.
+	Foreground fyne.ThemeColorName
+
+	// Name of the background color.
+	Background fyne.ThemeColorName
+
+	// The contrast ratio of the colors.
+	Ratio float64
+
+	// The minimum contrast ratio required for the pair.
+	Minimum float64
+}
+
+// Passed reports whether the contrast ratio meets the minimum.
+func (c ContrastCheck) Passed() bool {
+	return c.Ratio >= c.Minimum
+}
+
+func (c ContrastCheck) String() string {
+	return fmt.Sprintf("%s on %s (%s): %.2f:1 (minimum %.1f:1)", c.Foreground, c.Background, variantName(c.Variant), c.Ratio, c.Minimum)
+}
+
+// ContrastReport is the result of checking the contrast of all relevant color pairs of a theme.
+type ContrastReport struct {
+	// The checks for all color pairs of both variants.
+	Checks []ContrastCheck
+}
+
+// Failures returns the checks which did not pass.
+func (r *ContrastReport) Failures() []ContrastCheck {
+	var failures []ContrastCheck
+	for _, c := range r.Checks {
+		if !c.Passed() {
+			failures = append(failures, c)
+		}
+	}
+	return failures
+}
+
+// Passed reports whether all checks passed.
+func (r *ContrastReport) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// contrastPair is a pair of colors which Fyne renders on top of each other.
+type contrastPair struct {
+	fg, bg  fyne.ThemeColorName
+	minimum float64
+}
+
+var contrastPairs = []contrastPair{
+	{theme.ColorNameForeground, theme.ColorNameBackground, MinContrastText},
+	{theme.ColorNameForeground, theme.ColorNameButton, MinContrastText},
+	{theme.ColorNameForeground, theme.ColorNameHeaderBackground, MinContrastText},
+	{theme.ColorNameForeground, theme.ColorNameInputBackground, MinContrastText},
+	{theme.ColorNameForeground, theme.ColorNameMenuBackground, MinContrastText},
+	{theme.ColorNameForeground, theme.ColorNameOverlayBackground, MinContrastText},
+	{theme.ColorNameForegroundOnPrimary, theme.ColorNamePrimary, MinContrastText},
+	{theme.ColorNameForegroundOnError, theme.ColorNameError, MinContrastText},
+	{theme.ColorNameForegroundOnSuccess, theme.ColorNameSuccess, MinContrastText},
+	{theme.ColorNameForegroundOnWarning, theme.ColorNameWarning, MinContrastText},
+	{theme.ColorNamePlaceHolder, theme.ColorNameInputBackground, MinContrastText},
+	{theme.ColorNameHyperlink, theme.ColorNameBackground, MinContrastText},
+	{theme.ColorNamePrimary, theme.ColorNameBackground, MinContrastText},
+	{theme.ColorNameError, theme.ColorNameBackground, MinContrastText},
+	{theme.ColorNameSuccess, theme.ColorNameBackground, MinContrastText},
+	{theme.ColorNameWarning, theme.ColorNameBackground, MinContrastText},
+	{theme.ColorNameInputBorder, theme.ColorNameBackground, MinContrastUI},
+	{theme.ColorNameFocus, theme.ColorNameBackground, MinContrastUI},
+}
+
+// CheckContrast checks the contrast of the color pairs of th, which Fyne renders on top of each other,
+// for the light and the dark variant.
+//
+// Text pairs like foreground on background or foreground on primary require a contrast ratio of [MinContrastText].
+// Pairs of user interface components like input border on background require [MinContrastUI].
+func CheckContrast(th fyne.Theme) *ContrastReport {
+	r := &ContrastReport{}
+	for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+		for _, p := range contrastPairs {
+			bg := th.Color(p.bg, v)
+			if _, _, _, a := bg.RGBA(); a != 0xffff {
+				bg = blend(bg, th.Color(theme.ColorNameBackground, v))
+			}
+			r.Checks = append(r.Checks, ContrastCheck{
+				Variant:    v,
+				Foreground: p.fg,
+				Background: p.bg,
+				Ratio:      ContrastRatio(th.Color(p.fg, v), bg),
+				Minimum:    p.minimum,
+			})
+		}
+	}
+	return r
+}
+
+func variantName(v fyne.ThemeVariant) string {
+	switch v {
+	case theme.VariantLight:
+		return "light"
+	case theme.VariantDark:
+		return "dark"
+	}
+	return fmt.Sprintf("variant %d", v)
+}
@@ -0,0 +1,82 @@
+package theme_test
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+
+	kxtheme "github.com/ErikKalkoken/fyne-kx/theme"
+)
+
+func TestContrastRatio(t *testing.T) {
+	t.Run("should return maximum ratio for black on white", func(t *testing.T) {
+		assert.InDelta(t, 21, kxtheme.ContrastRatio(color.Black, color.White), 0.001)
+	})
+	t.Run("should return same ratio for both orders", func(t *testing.T) {
+		assert.InDelta(t, 21, kxtheme.ContrastRatio(color.White, color.Black), 0.001)
+	})
+	t.Run("should return minimum ratio for same colors", func(t *testing.T) {
+		c := color.NRGBA{R: 0x00, G: 0x96, B: 0x88, A: 0xff}
+		assert.InDelta(t, 1, kxtheme.ContrastRatio(c, c), 0.001)
+	})
+	t.Run("should return ratio for gray on white", func(t *testing.T) {
+		c := color.NRGBA{R: 0x76, G: 0x76, B: 0x76, A: 0xff}
+		assert.InDelta(t, 4.54, kxtheme.ContrastRatio(c, color.White), 0.01)
+	})
+	t.Run("should blend translucent foreground onto background", func(t *testing.T) {
+		c := color.NRGBA{A: 0x00}
+		assert.InDelta(t, 1, kxtheme.ContrastRatio(c, color.White), 0.001)
+	})
+}
+
+func TestCheckContrast(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should check both variants", func(t *testing.T) {
+		r := kxtheme.CheckContrast(theme.DefaultTheme())
+		var light, dark int
+		for _, c := range r.Checks {
+			switch c.Variant {
+			case theme.VariantLight:
+				light++
+			case theme.VariantDark:
+				dark++
+			}
+		}
+		assert.Greater(t, light, 0)
+		assert.Equal(t, light, dark)
+	})
+	t.Run("should report pass for foreground on background of default theme", func(t *testing.T) {
+		r := kxtheme.CheckContrast(theme.DefaultTheme())
+		for _, c := range r.Checks {
+			if c.Foreground == theme.ColorNameForeground && c.Background == theme.ColorNameBackground {
+				assert.True(t, c.Passed(), c)
+			}
+		}
+	})
+	t.Run("should report failures", func(t *testing.T) {
+		th := kxtheme.NewBuilder(nil).
+			Color(theme.ColorNameForeground, color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}).
+			Color(theme.ColorNameBackground, color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}).
+			Build()
+		r := kxtheme.CheckContrast(th)
+		assert.False(t, r.Passed())
+		var found bool
+		for _, c := range r.Failures() {
+			assert.False(t, c.Passed())
+			if c.Foreground == theme.ColorNameForeground && c.Background == theme.ColorNameBackground {
+				assert.InDelta(t, 1, c.Ratio, 0.001)
+				assert.Equal(t, kxtheme.MinContrastText, c.Minimum)
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+	t.Run("should report pass when all checks pass", func(t *testing.T) {
+		r := &kxtheme.ContrastReport{Checks: []kxtheme.ContrastCheck{{Ratio: 5, Minimum: kxtheme.MinContrastText}}}
+		assert.True(t, r.Passed())
+		assert.Empty(t, r.Failures())
+	})
+}
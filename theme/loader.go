@@ -0,0 +1,396 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/BurntSushi/toml"
+)
+
+// Format is the format of a theme definition.
+type Format uint
+
+const (
+	FormatJSON Format = iota
+	FormatTOML
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "JSON"
+	case FormatTOML:
+		return "TOML"
+	}
+	return fmt.Sprintf("Format(%d)", f)
+}
+
+// DefinitionError is an error in a theme definition.
+type DefinitionError struct {
+	// Line of the definition where the error occurred, starting at 1. Zero when unknown.
+	Line int
+
+	// Text of the line where the error occurred.
+	Text string
+
+	Err error
+}
+
+func (e *DefinitionError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %s near: %s", e.Line, e.Err, e.Text)
+}
+
+func (e *DefinitionError) Unwrap() error {
+	return e.Err
+}
+
+// DefinitionErrors is the error returned when a theme definition has one or more errors.
+// The errors are ordered by line.
+type DefinitionErrors []*DefinitionError
+
+func (e DefinitionErrors) Error() string {
+	s := make([]string, len(e))
+	for i, x := range e {
+		s[i] = x.Error()
+	}
+	return fmt.Sprintf("theme definition has %d errors: %s", len(e), strings.Join(s, "; "))
+}
+
+// Is reports whether any of the definition errors matches target.
+func (e DefinitionErrors) Is(target error) bool {
+	for _, x := range e {
+		if errors.Is(x, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// definition is the structure of a theme definition.
+type definition struct {
+	Colors  map[string]map[string]string `json:"colors" toml:"colors"`
+	Fonts   map[string]string            `json:"fonts" toml:"fonts"`
+	Icons   map[string]string            `json:"icons" toml:"icons"`
+	Sizes   map[string]float32           `json:"sizes" toml:"sizes"`
+	Variant string                       `json:"variant" toml:"variant"`
+}
+
+var definitionKeys = []string{"colors", "fonts", "icons", "sizes", "variant"}
+
+// LoadFile returns a new theme from a theme definition file.
+// The format is determined by the file extension, which must be ".json" or ".toml".
+// Paths of font and icon files are relative to the directory of the theme definition file.
+//
+// See [Load] for the structure of theme definitions.
+func LoadFile(path string) (fyne.Theme, error) {
+	var format Format
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		format = FormatJSON
+	case ".toml":
+		format = FormatTOML
+	default:
+		return nil, fmt.Errorf("theme definition %s: unsupported file extension %q", path, ext)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	th, err := Load(data, format, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("theme definition %s: %w", path, err)
+	}
+	return th, nil
+}
+
+// Load returns a new theme from a theme definition in format.
+// Paths of font and icon files are relative to dir.
+//
+// A theme definition can override colors per variant, sizes, fonts and icons of the default Fyne theme.
+// Colors are hex values in the form "#rgb", "#rrggbb" or "#rrggbbaa".
+// The names of colors, sizes and icons are the values of the Fyne constants,
+// e.g. "primary" for [theme.ColorNamePrimary] and "iconInline" for [theme.SizeNameInlineIcon].
+// Fonts are named "regular", "bold", "italic", "boldItalic", "monospace" and "symbol".
+// The optional variant "light" or "dark" fixes the theme variant.
+//
+// Here is an example in TOML:
+//
+//	variant = "dark"
+//
+//	[colors.light]
+//	primary = "#009688"
+//
+//	[colors.dark]
+//	primary = "#4db6ac"
+//	background = "#121212"
+//
+//	[sizes]
+//	text = 15
+//
+//	[fonts]
+//	regular = "fonts/Inter-Regular.ttf"
+//
+//	[icons]
+//	home = "icons/home.svg"
+//
+// All errors of a definition are reported together as [DefinitionErrors],
+// which contain the line and its text where an error occurred.
+func Load(data []byte, format Format, dir string) (fyne.Theme, error) {
+	var def definition
+	var errs DefinitionErrors
+	switch format {
+	case FormatJSON:
+		errs = decodeJSON(data, &def)
+	case FormatTOML:
+		errs = decodeTOML(data, &def)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+	if len(errs) > 0 {
+		return nil, sortByLine(errs)
+	}
+	b := NewBuilder(theme.DefaultTheme())
+	addErr := func(err error, path ...string) {
+		errs = append(errs, newDefinitionError(data, locate(data, format, path...), err))
+	}
+	switch def.Variant {
+	case "":
+	case "light":
+		b.FixedVariant(theme.VariantLight)
+	case "dark":
+		b.FixedVariant(theme.VariantDark)
+	default:
+		addErr(fmt.Errorf("unknown variant %q", def.Variant), "variant")
+	}
+	for variant, colors := range def.Colors {
+		var v fyne.ThemeVariant
+		switch variant {
+		case "light":
+			v = theme.VariantLight
+		case "dark":
+			v = theme.VariantDark
+		default:
+			addErr(fmt.Errorf("unknown color variant %q", variant), "colors", variant)
+			continue
+		}
+		for name, hex := range colors {
+			if !isColorName(name) {
+				addErr(fmt.Errorf("unknown color name %q", name), "colors", variant, name)
+				continue
+			}
+			c, err := parseHexColor(hex)
+			if err != nil {
+				addErr(err, "colors", variant, name)
+				continue
+			}
+			b.ColorForVariant(fyne.ThemeColorName(name), v, c)
+		}
+	}
+	for name, s := range def.Sizes {
+		if !isSizeName(name) {
+			addErr(fmt.Errorf("unknown size name %q", name), "sizes", name)
+			continue
+		}
+		b.Size(fyne.ThemeSizeName(name), s)
+	}
+	for name, path := range def.Fonts {
+		style, ok := fontStyles[name]
+		if !ok {
+			addErr(fmt.Errorf("unknown font name %q", name), "fonts", name)
+			continue
+		}
+		r, err := loadResource(dir, path)
+		if err != nil {
+			addErr(err, "fonts", name)
+			continue
+		}
+		b.Font(style, r)
+	}
+	for name, path := range def.Icons {
+		r, err := loadResource(dir, path)
+		if err != nil {
+			addErr(err, "icons", name)
+			continue
+		}
+		b.Icon(fyne.ThemeIconName(name), r)
+	}
+	if len(errs) > 0 {
+		return nil, sortByLine(errs)
+	}
+	return b.Build(), nil
+}
+
+func sortByLine(errs DefinitionErrors) DefinitionErrors {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+	return errs
+}
+
+func decodeJSON(data []byte, def *definition) DefinitionErrors {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return DefinitionErrors{jsonError(data, err)}
+	}
+	var errs DefinitionErrors
+	for k := range keys {
+		if !isDefinitionKey(k) {
+			errs = append(errs, newDefinitionError(data, locate(data, FormatJSON, k), fmt.Errorf("unknown key %q", k)))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if err := json.Unmarshal(data, def); err != nil {
+		return DefinitionErrors{jsonError(data, err)}
+	}
+	return nil
+}
+
+func jsonError(data []byte, err error) *DefinitionError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return newDefinitionError(data, lineAt(data, syntaxErr.Offset), err)
+	case errors.As(err, &typeErr):
+		return newDefinitionError(data, lineAt(data, typeErr.Offset), err)
+	}
+	return &DefinitionError{Err: err}
+}
+
+func decodeTOML(data []byte, def *definition) DefinitionErrors {
+	md, err := toml.Decode(string(data), def)
+	if err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			msg := parseErr.Message
+			if msg == "" {
+				msg = tomlErrorPrefix.ReplaceAllString(parseErr.Error(), "")
+			}
+			return DefinitionErrors{newDefinitionError(data, parseErr.Position.Line, errors.New(msg))}
+		}
+		return DefinitionErrors{{Err: err}}
+	}
+	var errs DefinitionErrors
+	for _, k := range md.Undecoded() {
+		if len(k) != 1 {
+			continue // only top level keys can be unknown
+		}
+		errs = append(errs, newDefinitionError(data, locate(data, FormatTOML, k...), fmt.Errorf("unknown key %q", k.String())))
+	}
+	return errs
+}
+
+// tomlErrorPrefix matches the position prefix of TOML parse errors, which is reported separately.
+var tomlErrorPrefix = regexp.MustCompile(`^toml: line \d+( \(last key .*?\))?: `)
+
+func isDefinitionKey(s string) bool {
+	for _, k := range definitionKeys {
+		if k == s {
+			return true
+		}
+	}
+	return false
+}
+
+func newDefinitionError(data []byte, line int, err error) *DefinitionError {
+	e := &DefinitionError{Line: line, Err: err}
+	if line > 0 {
+		lines := strings.Split(string(data), "\n")
+		if line <= len(lines) {
+			e.Text = strings.TrimSpace(lines[line-1])
+		}
+	}
+	return e
+}
+
+// lineAt returns the line number of the byte at offset in data.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// locate returns the line number of the key at path in data or 0 if it is not found.
+// Each key of the path is searched after the position of its parent key.
+func locate(data []byte, format Format, path ...string) int {
+	s := string(data)
+	pos := 0
+	for _, key := range path {
+		i := indexKey(s[pos:], format, key)
+		if i < 0 {
+			return 0
+		}
+		pos += i
+	}
+	return lineAt(data, int64(pos))
+}
+
+// indexKey returns the index of the first occurrence of key in s or -1 if there is none.
+func indexKey(s string, format Format, key string) int {
+	if format == FormatJSON {
+		return strings.Index(s, strconv.Quote(key))
+	}
+	isKeyChar := func(c byte) bool {
+		return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+	}
+	for start := 0; start < len(s); {
+		i := strings.Index(s[start:], key)
+		if i < 0 {
+			return -1
+		}
+		i += start
+		end := i + len(key)
+		if (i == 0 || !isKeyChar(s[i-1])) && (end == len(s) || !isKeyChar(s[end])) {
+			return i
+		}
+		start = i + 1
+	}
+	return -1
+}
+
+// parseHexColor returns the color for a hex value in the form "#rgb", "#rrggbb" or "#rrggbbaa".
+func parseHexColor(s string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == len(s) {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q: must start with #", s)
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q: must have 3, 6 or 8 hex digits", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q: not a hex value", s)
+	}
+	c := color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}
+	return c, nil
+}
+
+func loadResource(dir, path string) (fyne.Resource, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return fyne.LoadResourceFromPath(path)
+}
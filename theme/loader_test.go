@@ -0,0 +1,154 @@
+package theme_test
+
+import (
+	"errors"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kxtheme "github.com/ErikKalkoken/fyne-kx/theme"
+)
+
+func TestLoad(t *testing.T) {
+	test.NewTempApp(t)
+	teal := color.NRGBA{R: 0x00, G: 0x96, B: 0x88, A: 0xff}
+	dark := color.NRGBA{R: 0x12, G: 0x12, B: 0x12, A: 0x80}
+	t.Run("can load JSON definition", func(t *testing.T) {
+		data := `{
+  "colors": {
+    "light": {"primary": "#009688"},
+    "dark": {"background": "#12121280"}
+  },
+  "sizes": {"text": 15}
+}`
+		th, err := kxtheme.Load([]byte(data), kxtheme.FormatJSON, "")
+		require.NoError(t, err)
+		assert.Equal(t, teal, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+		assert.Equal(t, dark, th.Color(theme.ColorNameBackground, theme.VariantDark))
+		assert.Equal(t, float32(15), th.Size(theme.SizeNameText))
+	})
+	t.Run("can load TOML definition", func(t *testing.T) {
+		data := `
+variant = "dark"
+
+[colors.dark]
+primary = "#009688"
+
+[sizes]
+text = 15
+`
+		th, err := kxtheme.Load([]byte(data), kxtheme.FormatTOML, "")
+		require.NoError(t, err)
+		assert.Equal(t, teal, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+		assert.Equal(t, float32(15), th.Size(theme.SizeNameText))
+	})
+	t.Run("can parse short hex colors", func(t *testing.T) {
+		data := `{"colors": {"light": {"primary": "#f00"}}}`
+		th, err := kxtheme.Load([]byte(data), kxtheme.FormatJSON, "")
+		require.NoError(t, err)
+		assert.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+	})
+	t.Run("should report unknown names with line", func(t *testing.T) {
+		data := `{
+  "colors": {
+    "light": {
+      "primry": "#009688"
+    }
+  },
+  "sizes": {
+    "txt": 15
+  }
+}`
+		_, err := kxtheme.Load([]byte(data), kxtheme.FormatJSON, "")
+		var errs kxtheme.DefinitionErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, 4, errs[0].Line)
+		assert.Equal(t, `"primry": "#009688"`, errs[0].Text)
+		assert.Equal(t, 8, errs[1].Line)
+		assert.Equal(t, `line 8: unknown size name "txt" near: "txt": 15`, errs[1].Error())
+	})
+	t.Run("should report unknown names with line in TOML", func(t *testing.T) {
+		data := `[colors.light]
+primary = "#009688"
+primry = "#009688"
+
+[colors.dark]
+primry = "#009688"
+`
+		_, err := kxtheme.Load([]byte(data), kxtheme.FormatTOML, "")
+		var errs kxtheme.DefinitionErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, 3, errs[0].Line)
+		assert.Equal(t, 6, errs[1].Line)
+	})
+	t.Run("should report invalid colors", func(t *testing.T) {
+		for _, c := range []string{"009688", "#00968", "#00968g"} {
+			data := `{"colors": {"light": {"primary": "` + c + `"}}}`
+			_, err := kxtheme.Load([]byte(data), kxtheme.FormatJSON, "")
+			assert.Error(t, err, c)
+		}
+	})
+	t.Run("should report unknown keys", func(t *testing.T) {
+		data := `{
+  "colours": {}
+}`
+		_, err := kxtheme.Load([]byte(data), kxtheme.FormatJSON, "")
+		var errs kxtheme.DefinitionErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, 2, errs[0].Line)
+	})
+	t.Run("should report syntax errors with line", func(t *testing.T) {
+		data := `{
+  "sizes": {
+    "text": 15,
+  }
+}`
+		_, err := kxtheme.Load([]byte(data), kxtheme.FormatJSON, "")
+		var errs kxtheme.DefinitionErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, 4, errs[0].Line)
+	})
+	t.Run("should report TOML syntax errors with line", func(t *testing.T) {
+		data := `[sizes]
+text = 15
+padding = 12px
+`
+		_, err := kxtheme.Load([]byte(data), kxtheme.FormatTOML, "")
+		var errs kxtheme.DefinitionErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, 3, errs[0].Line)
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	test.NewTempApp(t)
+	dir := t.TempDir()
+	icon := []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "home.svg"), icon, 0o644))
+	t.Run("can load file with resources", func(t *testing.T) {
+		path := filepath.Join(dir, "theme.toml")
+		require.NoError(t, os.WriteFile(path, []byte("[icons]\nhome = \"home.svg\"\n"), 0o644))
+		th, err := kxtheme.LoadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, icon, th.Icon(theme.IconNameHome).Content())
+		assert.Equal(t, theme.DefaultTheme().Icon(theme.IconNameSearch), th.Icon(theme.IconNameSearch))
+	})
+	t.Run("should report missing resources", func(t *testing.T) {
+		path := filepath.Join(dir, "theme.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"fonts": {"regular": "missing.ttf"}}`), 0o644))
+		_, err := kxtheme.LoadFile(path)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+	t.Run("should report unsupported extension", func(t *testing.T) {
+		_, err := kxtheme.LoadFile(filepath.Join(dir, "theme.yaml"))
+		assert.Error(t, err)
+	})
+}
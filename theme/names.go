@@ -0,0 +1,89 @@
+package theme
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// colorNames are the names of all colors of the Fyne theme.
+var colorNames = []fyne.ThemeColorName{
+	theme.ColorNameBackground,
+	theme.ColorNameButton,
+	theme.ColorNameDisabled,
+	theme.ColorNameDisabledButton,
+	theme.ColorNameError,
+	theme.ColorNameFocus,
+	theme.ColorNameForeground,
+	theme.ColorNameForegroundOnError,
+	theme.ColorNameForegroundOnPrimary,
+	theme.ColorNameForegroundOnSuccess,
+	theme.ColorNameForegroundOnWarning,
+	theme.ColorNameHeaderBackground,
+	theme.ColorNameHover,
+	theme.ColorNameHyperlink,
+	theme.ColorNameInputBackground,
+	theme.ColorNameInputBorder,
+	theme.ColorNameMenuBackground,
+	theme.ColorNameOverlayBackground,
+	theme.ColorNamePlaceHolder,
+	theme.ColorNamePressed,
+	theme.ColorNamePrimary,
+	theme.ColorNameScrollBar,
+	theme.ColorNameScrollBarBackground,
+	theme.ColorNameSelection,
+	theme.ColorNameSeparator,
+	theme.ColorNameShadow,
+	theme.ColorNameSuccess,
+	theme.ColorNameWarning,
+}
+
+// sizeNames are the names of all sizes of the Fyne theme.
+var sizeNames = []fyne.ThemeSizeName{
+	theme.SizeNameCaptionText,
+	theme.SizeNameHeadingText,
+	theme.SizeNameInlineIcon,
+	theme.SizeNameInnerPadding,
+	theme.SizeNameInputBorder,
+	theme.SizeNameInputRadius,
+	theme.SizeNameLineSpacing,
+	theme.SizeNamePadding,
+	theme.SizeNameScrollBar,
+	theme.SizeNameScrollBarRadius,
+	theme.SizeNameScrollBarSmall,
+	theme.SizeNameSelectionRadius,
+	theme.SizeNameSeparatorThickness,
+	theme.SizeNameSubHeadingText,
+	theme.SizeNameText,
+	theme.SizeNameWindowButtonHeight,
+	theme.SizeNameWindowButtonIcon,
+	theme.SizeNameWindowButtonRadius,
+	theme.SizeNameWindowTitleBarHeight,
+}
+
+// fontStyles maps the names of fonts in theme definitions to their text styles.
+var fontStyles = map[string]fyne.TextStyle{
+	"regular":    {},
+	"bold":       {Bold: true},
+	"italic":     {Italic: true},
+	"boldItalic": {Bold: true, Italic: true},
+	"monospace":  {Monospace: true},
+	"symbol":     {Symbol: true},
+}
+
+func isColorName(s string) bool {
+	for _, n := range colorNames {
+		if string(n) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isSizeName(s string) bool {
+	for _, n := range sizeNames {
+		if string(n) == s {
+			return true
+		}
+	}
+	return false
+}
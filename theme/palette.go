@@ -0,0 +1,192 @@
+package theme
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Palette is a complete set of theme colors for the light and the dark variant, which is derived from seed colors.
+//
+// Colors are taken from tonal palettes following the rules of Material Design:
+// Each tonal palette keeps the hue and chroma of its seed color and varies the tone (perceived lightness) from 0 (black) to 100 (white).
+// For example primary is tone 40 of the primary palette in the light variant and tone 80 in the dark variant,
+// and backgrounds and text are very light and very dark tones of a neutral palette.
+//
+// Here is how to create a theme from a seed color:
+//
+//	p := kxtheme.NewPalette(color.NRGBA{R: 0x00, G: 0x96, B: 0x88, A: 0xff}, nil)
+//	app.Settings().SetTheme(p.Theme())
+type Palette struct {
+	// Colors for the light variant.
+	Light map[fyne.ThemeColorName]color.Color
+
+	// Colors for the dark variant.
+	Dark map[fyne.ThemeColorName]color.Color
+}
+
+// Hues of the tonal palettes for status colors.
+const (
+	hueError   = 30
+	hueSuccess = 140
+	hueWarning = 65
+)
+
+// Alpha values of translucent colors.
+const (
+	alphaFocus     = 0xb3 // 70%, so the focus ring keeps a contrast of 3:1 on the background
+	alphaHover     = 0x14 // 8% as the Material hover state layer
+	alphaPressed   = 0x1a // 10% as the Material pressed state layer
+	alphaScrollBar = 0x99
+	alphaSelection = 0x3f
+)
+
+// NewPalette returns a new [Palette] derived from the seed color primary.
+//
+// The optional seed color neutral tints backgrounds, surfaces and text.
+// When it is nil, the neutral colors are derived from primary with a very low chroma.
+func NewPalette(primary, neutral color.Color) *Palette {
+	p := newTonalPalette(primary)
+	if p.chroma >= 5 && p.chroma < 48 {
+		p.chroma = 48 // Material uses a minimum chroma for primary palettes of colorful seeds
+	}
+	var n tonalPalette
+	if neutral != nil {
+		n = newTonalPalette(neutral)
+		if n.chroma > 16 {
+			n.chroma = 16
+		}
+	} else {
+		n = tonalPalette{hue: p.hue, chroma: 2}
+	}
+	e := tonalPalette{hue: hueError, chroma: 80}
+	s := tonalPalette{hue: hueSuccess, chroma: 60}
+	w := tonalPalette{hue: hueWarning, chroma: 70}
+	black := color.NRGBA{A: 0xff}
+
+	light := map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameBackground:          n.tone(99),
+		theme.ColorNameButton:              n.tone(95),
+		theme.ColorNameDisabled:            n.tone(80),
+		theme.ColorNameDisabledButton:      n.tone(95),
+		theme.ColorNameError:               e.tone(40),
+		theme.ColorNameFocus:               withAlpha(p.tone(40), alphaFocus),
+		theme.ColorNameForeground:          n.tone(10),
+		theme.ColorNameForegroundOnError:   e.tone(100),
+		theme.ColorNameForegroundOnPrimary: p.tone(100),
+		theme.ColorNameForegroundOnSuccess: s.tone(100),
+		theme.ColorNameForegroundOnWarning: w.tone(100),
+		theme.ColorNameHeaderBackground:    n.tone(96),
+		theme.ColorNameHover:               withAlpha(n.tone(10), alphaHover),
+		theme.ColorNameHyperlink:           p.tone(40),
+		theme.ColorNameInputBackground:     n.tone(94),
+		theme.ColorNameInputBorder:         n.tone(60),
+		theme.ColorNameMenuBackground:      n.tone(95),
+		theme.ColorNameOverlayBackground:   n.tone(100),
+		theme.ColorNamePlaceHolder:         n.tone(40),
+		theme.ColorNamePressed:             withAlpha(n.tone(10), alphaPressed),
+		theme.ColorNamePrimary:             p.tone(40),
+		theme.ColorNameScrollBar:           withAlpha(n.tone(10), alphaScrollBar),
+		theme.ColorNameScrollBarBackground: n.tone(90),
+		theme.ColorNameSelection:           withAlpha(p.tone(40), alphaSelection),
+		theme.ColorNameSeparator:           n.tone(90),
+		theme.ColorNameShadow:              withAlpha(black, 0x33),
+		theme.ColorNameSuccess:             s.tone(40),
+		theme.ColorNameWarning:             w.tone(45),
+	}
+	dark := map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameBackground:          n.tone(6),
+		theme.ColorNameButton:              n.tone(17),
+		theme.ColorNameDisabled:            n.tone(35),
+		theme.ColorNameDisabledButton:      n.tone(17),
+		theme.ColorNameError:               e.tone(80),
+		theme.ColorNameFocus:               withAlpha(p.tone(80), alphaFocus),
+		theme.ColorNameForeground:          n.tone(90),
+		theme.ColorNameForegroundOnError:   e.tone(20),
+		theme.ColorNameForegroundOnPrimary: p.tone(20),
+		theme.ColorNameForegroundOnSuccess: s.tone(20),
+		theme.ColorNameForegroundOnWarning: w.tone(20),
+		theme.ColorNameHeaderBackground:    n.tone(10),
+		theme.ColorNameHover:               withAlpha(n.tone(90), alphaHover),
+		theme.ColorNameHyperlink:           p.tone(80),
+		theme.ColorNameInputBackground:     n.tone(22),
+		theme.ColorNameInputBorder:         n.tone(60),
+		theme.ColorNameMenuBackground:      n.tone(17),
+		theme.ColorNameOverlayBackground:   n.tone(12),
+		theme.ColorNamePlaceHolder:         n.tone(70),
+		theme.ColorNamePressed:             withAlpha(n.tone(90), alphaPressed),
+		theme.ColorNamePrimary:             p.tone(80),
+		theme.ColorNameScrollBar:           withAlpha(n.tone(90), alphaScrollBar),
+		theme.ColorNameScrollBarBackground: n.tone(17),
+		theme.ColorNameSelection:           withAlpha(p.tone(80), alphaSelection),
+		theme.ColorNameSeparator:           n.tone(30),
+		theme.ColorNameShadow:              withAlpha(black, 0x66),
+		theme.ColorNameSuccess:             s.tone(80),
+		theme.ColorNameWarning:             w.tone(80),
+	}
+	return &Palette{Light: light, Dark: dark}
+}
+
+// Color returns the color with name for variant or nil if the palette does not have it.
+func (p *Palette) Color(name fyne.ThemeColorName, v fyne.ThemeVariant) color.Color {
+	if v == theme.VariantDark {
+		return p.Dark[name]
+	}
+	return p.Light[name]
+}
+
+// Builder returns a new [Builder] for a theme based on base, which uses the colors of the palette.
+// When base is nil the default Fyne theme is used.
+func (p *Palette) Builder(base fyne.Theme) *Builder {
+	b := NewBuilder(base)
+	for name, c := range p.Light {
+		b.ColorForVariant(name, theme.VariantLight, c)
+	}
+	for name, c := range p.Dark {
+		b.ColorForVariant(name, theme.VariantDark, c)
+	}
+	return b
+}
+
+// Theme returns a new theme based on the default Fyne theme, which uses the colors of the palette.
+func (p *Palette) Theme() fyne.Theme {
+	return p.Builder(nil).Build()
+}
+
+// tonalPalette is a range of colors with the same hue and chroma, which differ only in tone.
+type tonalPalette struct {
+	hue    float64
+	chroma float64
+}
+
+func newTonalPalette(seed color.Color) tonalPalette {
+	c := toLCh(seed)
+	return tonalPalette{hue: c.h, chroma: c.c}
+}
+
+// tone returns the color of the palette with tone t from 0 (black) to 100 (white).
+// The chroma is reduced when needed to fit the color into the sRGB gamut.
+func (p tonalPalette) tone(t float64) color.NRGBA {
+	c := lch{l: t, c: p.chroma, h: p.hue}
+	if c.inGamut() {
+		return c.toNRGBA()
+	}
+	lo, hi := 0.0, p.chroma
+	for i := 0; i < 20; i++ {
+		c.c = (lo + hi) / 2
+		if c.inGamut() {
+			lo = c.c
+		} else {
+			hi = c.c
+		}
+	}
+	c.c = lo
+	return c.toNRGBA()
+}
+
+// withAlpha returns c with the alpha value a.
+func withAlpha(c color.NRGBA, a uint8) color.NRGBA {
+	c.A = a
+	return c
+}
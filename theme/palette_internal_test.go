@@ -0,0 +1,52 @@
+package theme
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLCh(t *testing.T) {
+	t.Run("should convert colors to LCh and back", func(t *testing.T) {
+		for _, c := range []color.NRGBA{
+			{A: 0xff},
+			{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			{R: 0x00, G: 0x96, B: 0x88, A: 0xff},
+			{R: 0xf4, G: 0x43, B: 0x36, A: 0xff},
+		} {
+			assert.Equal(t, c, toLCh(c).toNRGBA())
+		}
+	})
+	t.Run("should return lightness of colors", func(t *testing.T) {
+		assert.InDelta(t, 0, toLCh(color.Black).l, 0.01)
+		assert.InDelta(t, 100, toLCh(color.White).l, 0.01)
+		assert.InDelta(t, 0, toLCh(color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}).c, 0.01)
+	})
+}
+
+func TestTonalPalette(t *testing.T) {
+	p := newTonalPalette(color.NRGBA{R: 0x00, G: 0x96, B: 0x88, A: 0xff})
+	t.Run("should return tones with requested lightness", func(t *testing.T) {
+		for _, tone := range []float64{10, 40, 80, 95} {
+			assert.InDelta(t, tone, toLCh(p.tone(tone)).l, 0.5)
+		}
+	})
+	t.Run("should keep hue", func(t *testing.T) {
+		for _, tone := range []float64{30, 50, 70} {
+			assert.InDelta(t, p.hue, toLCh(p.tone(tone)).h, 2)
+		}
+	})
+	t.Run("should return black and white for extreme tones", func(t *testing.T) {
+		assert.Equal(t, color.NRGBA{A: 0xff}, p.tone(0))
+		assert.Equal(t, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, p.tone(100))
+	})
+	t.Run("should reduce chroma to stay in gamut", func(t *testing.T) {
+		p := tonalPalette{hue: 140, chroma: 200}
+		c := toLCh(p.tone(50))
+		assert.InDelta(t, 50, c.l, 0.5)
+		assert.Less(t, c.c, 200.0)
+		assert.False(t, math.IsNaN(c.c))
+	})
+}
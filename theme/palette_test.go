@@ -0,0 +1,79 @@
+package theme_test
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+
+	kxtheme "github.com/ErikKalkoken/fyne-kx/theme"
+)
+
+func TestPalette(t *testing.T) {
+	test.NewTempApp(t)
+	seed := color.NRGBA{R: 0x00, G: 0x96, B: 0x88, A: 0xff}
+	colorNames := []fyne.ThemeColorName{
+		theme.ColorNameBackground,
+		theme.ColorNameDisabled,
+		theme.ColorNameFocus,
+		theme.ColorNameForeground,
+		theme.ColorNameHover,
+		theme.ColorNameInputBorder,
+		theme.ColorNamePressed,
+		theme.ColorNamePrimary,
+		theme.ColorNameSelection,
+	}
+	t.Run("should create colors for both variants", func(t *testing.T) {
+		p := kxtheme.NewPalette(seed, nil)
+		assert.Len(t, p.Light, 28)
+		assert.Len(t, p.Dark, 28)
+		for _, n := range colorNames {
+			assert.NotNil(t, p.Color(n, theme.VariantLight), n)
+			assert.NotNil(t, p.Color(n, theme.VariantDark), n)
+		}
+	})
+	t.Run("should derive light primary from seed", func(t *testing.T) {
+		p := kxtheme.NewPalette(seed, nil)
+		c := color.NRGBAModel.Convert(p.Color(theme.ColorNamePrimary, theme.VariantLight)).(color.NRGBA)
+		assert.Greater(t, c.G, c.R)
+		assert.Greater(t, c.B, c.R)
+	})
+	t.Run("should use light primary on dark backgrounds", func(t *testing.T) {
+		p := kxtheme.NewPalette(seed, nil)
+		light := color.NRGBAModel.Convert(p.Color(theme.ColorNamePrimary, theme.VariantLight)).(color.NRGBA)
+		dark := color.NRGBAModel.Convert(p.Color(theme.ColorNamePrimary, theme.VariantDark)).(color.NRGBA)
+		assert.Greater(t, dark.G, light.G)
+	})
+	t.Run("can use neutral seed", func(t *testing.T) {
+		p1 := kxtheme.NewPalette(seed, nil)
+		p2 := kxtheme.NewPalette(seed, color.NRGBA{R: 0x80, G: 0x60, B: 0x40, A: 0xff})
+		assert.Equal(t, p1.Color(theme.ColorNamePrimary, theme.VariantLight), p2.Color(theme.ColorNamePrimary, theme.VariantLight))
+		assert.NotEqual(t, p1.Color(theme.ColorNameBackground, theme.VariantLight), p2.Color(theme.ColorNameBackground, theme.VariantLight))
+	})
+	t.Run("can create theme", func(t *testing.T) {
+		p := kxtheme.NewPalette(seed, nil)
+		th := p.Theme()
+		for _, n := range colorNames {
+			assert.Equal(t, p.Color(n, theme.VariantLight), th.Color(n, theme.VariantLight))
+			assert.Equal(t, p.Color(n, theme.VariantDark), th.Color(n, theme.VariantDark))
+		}
+	})
+	t.Run("should meet contrast requirements", func(t *testing.T) {
+		seeds := map[string]color.NRGBA{
+			"teal":   seed,
+			"yellow": {R: 0xff, G: 0xeb, B: 0x3b, A: 0xff},
+			"gray":   {R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+			"red":    {R: 0xf4, G: 0x43, B: 0x36, A: 0xff},
+			"purple": {R: 0x9c, G: 0x27, B: 0xb0, A: 0xff},
+		}
+		for name, s := range seeds {
+			for _, neutral := range []color.Color{nil, s} {
+				r := kxtheme.CheckContrast(kxtheme.NewPalette(s, neutral).Theme())
+				assert.Empty(t, r.Failures(), "seed %s with neutral %v", name, neutral)
+			}
+		}
+	})
+}
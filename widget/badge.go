@@ -51,20 +51,7 @@ func (w *Badge) Refresh() {
 func (w *Badge) updateBadge() {
 	th := w.Theme()
 	v := fyne.CurrentApp().Settings().ThemeVariant()
-	switch w.Importance {
-	case widget.DangerImportance:
-		w.background.FillColor = th.Color(theme.ColorNameError, v)
-	case widget.HighImportance:
-		w.background.FillColor = th.Color(theme.ColorNamePrimary, v)
-	case widget.LowImportance:
-		w.background.FillColor = th.Color(theme.ColorNameDisabled, v)
-	case widget.SuccessImportance:
-		w.background.FillColor = th.Color(theme.ColorNameSuccess, v)
-	case widget.WarningImportance:
-		w.background.FillColor = th.Color(theme.ColorNameWarning, v)
-	default:
-		w.background.FillColor = th.Color(theme.ColorNameInputBackground, v)
-	}
+	w.background.FillColor = th.Color(importanceColorName(w.Importance), v)
 	p := th.Size(theme.SizeNameInnerPadding)
 	s := w.label.MinSize().SubtractWidthHeight(p/2, p)
 	w.background.SetMinSize(s)
@@ -79,3 +66,20 @@ func (w *Badge) CreateRenderer() fyne.WidgetRenderer {
 		container.NewCenter(w.label),
 	))
 }
+
+// importanceColorName returns the name of the theme color for an importance.
+func importanceColorName(importance widget.Importance) fyne.ThemeColorName {
+	switch importance {
+	case widget.DangerImportance:
+		return theme.ColorNameError
+	case widget.HighImportance:
+		return theme.ColorNamePrimary
+	case widget.LowImportance:
+		return theme.ColorNameDisabled
+	case widget.SuccessImportance:
+		return theme.ColorNameSuccess
+	case widget.WarningImportance:
+		return theme.ColorNameWarning
+	}
+	return theme.ColorNameInputBackground
+}
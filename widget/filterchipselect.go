@@ -8,11 +8,11 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/ErikKalkoken/fyne-kx/internal/searchdialog"
 )
 
 // FilterChipSelect represents a filter chip widget that allows the user to select
@@ -45,7 +45,6 @@ type FilterChipSelect struct {
 	iconOn               *widget.Icon
 	iconOnPadded         *fyne.Container
 	iconTrailing         *widget.Icon
-	isMobile             bool
 	label                *widget.Label
 	minSize              fyne.Size // cached for hover/top pos calcs
 	resourceIconOn       fyne.Resource
@@ -79,7 +78,6 @@ func newFilterChipSelect(placeholder string, options []string, changed func(sele
 	w := &FilterChipSelect{
 		ClearLabel:           "Clear",
 		iconTrailing:         widget.NewIcon(theme.MenuDropDownIcon()),
-		isMobile:             fyne.CurrentDevice().IsMobile(),
 		OnChanged:            changed,
 		Text:                 placeholder,
 		resourceIconOn:       theme.ConfirmIcon(),
@@ -191,123 +189,38 @@ func (w *FilterChipSelect) showDropDownMenu() {
 }
 
 func (w *FilterChipSelect) showSearchDialog() {
-	itemsFiltered := sliceClone(w.Options)
-	if w.Selected != "" && !sliceContains(itemsFiltered, w.Selected) {
-		itemsFiltered = append(itemsFiltered, w.Selected)
+	options := sliceClone(w.Options)
+	if w.Selected != "" && !sliceContains(options, w.Selected) {
+		options = append(options, w.Selected)
 	}
 	if !w.SortDisabled {
-		sort.Slice(itemsFiltered, func(i, j int) bool {
-			return strings.ToLower(itemsFiltered[i]) < strings.ToLower(itemsFiltered[j])
+		sort.Slice(options, func(i, j int) bool {
+			return strings.ToLower(options[i]) < strings.ToLower(options[j])
 		})
 	}
-	var d dialog.Dialog
-	list := widget.NewList(
-		func() int {
-			return len(itemsFiltered)
-		},
-		func() fyne.CanvasObject {
-			icon := widget.NewIcon(IconBlankSvg)
-			if w.Selected == "" {
-				icon.Hide()
-			} else {
-				icon.Show()
-			}
-			return container.NewBorder(
-				nil,
-				nil,
-				icon,
-				nil,
-				widget.NewLabel(""),
-			)
+	var selected []string
+	if w.Selected != "" {
+		selected = []string{w.Selected}
+	}
+	d := searchdialog.New(searchdialog.Config[string]{
+		ClearLabel: w.ClearLabel,
+		IconButton: func(icon fyne.Resource, tapped func()) fyne.CanvasObject {
+			return NewIconButton(icon, tapped)
 		},
-		func(id widget.ListItemID, co fyne.CanvasObject) {
-			if id >= len(itemsFiltered) {
-				return
-			}
-			s := itemsFiltered[id]
-			box := co.(*fyne.Container).Objects
-			box[0].(*widget.Label).SetText(s)
-			if w.Selected == "" {
+		Items:     options,
+		NoEntries: len(w.Options) == 0,
+		OnSelected: func(s []string) {
+			if len(s) == 0 {
+				w.SetSelected("")
 				return
 			}
-			icon := box[1].(*widget.Icon)
-			if s == w.Selected {
-				icon.SetResource(theme.ConfirmIcon())
-			} else {
-				icon.SetResource(IconBlankSvg)
-			}
+			w.SetSelected(s[0])
 		},
-	)
-	list.OnSelected = func(id widget.ListItemID) {
-		if id >= len(itemsFiltered) {
-			return
-		}
-		w.SetSelected(itemsFiltered[id])
-		d.Hide()
-	}
-	list.HideSeparators = true
-	entry := widget.NewEntry()
-	entry.PlaceHolder = "Type to start searching..."
-	entry.ActionItem = NewIconButton(theme.CancelIcon(), func() {
-		entry.SetText("")
+		Selected: selected,
+		Title:    "Filter by " + w.Text,
+		Window:   w.window,
 	})
-	entry.OnChanged = func(search string) {
-		if len(search) < 2 {
-			itemsFiltered = sliceClone(w.Options)
-			list.Refresh()
-			return
-		}
-		itemsFiltered = make([]string, 0)
-		search2 := strings.ToLower(search)
-		for _, s := range w.Options {
-			if strings.Contains(strings.ToLower(s), search2) {
-				itemsFiltered = append(itemsFiltered, s)
-			}
-		}
-		list.Refresh()
-	}
-	clear := widget.NewButton("Clear", func() {
-		w.SetSelected("")
-		d.Hide()
-	})
-	if w.Selected != "" {
-		entry.Disable()
-		clear.Show()
-	} else {
-		clear.Hide()
-	}
-	empty := widget.NewLabel("No entries")
-	empty.Importance = widget.LowImportance
-	if len(w.Options) == 0 {
-		empty.Show()
-		entry.Disable()
-	} else {
-		empty.Hide()
-	}
-	c := container.NewBorder(
-		container.NewBorder(
-			nil,
-			clear,
-			nil,
-			widget.NewButton("Cancel", func() {
-				d.Hide()
-			}),
-			entry,
-		),
-		empty,
-		nil,
-		nil,
-		list,
-	)
-	d = dialog.NewCustomWithoutButtons("Filter by "+w.Text, c, w.window)
-	_, s := w.window.Canvas().InteractiveArea()
-	if w.isMobile {
-		d.Resize(fyne.NewSize(s.Width, s.Height))
-	} else {
-		d.Resize(fyne.NewSize(600, max(400, s.Height*0.8)))
-	}
 	d.Show()
-	w.window.Canvas().Focus(entry)
 }
 
 func (w *FilterChipSelect) updateState() {
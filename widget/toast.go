@@ -0,0 +1,275 @@
+package widget
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DefaultToastTimeout is the time after which a toast is dismissed,
+// when no timeout is specified.
+const DefaultToastTimeout = 3 * time.Second
+
+// Toast is a transient message, which is shown at the bottom of a window,
+// e.g. "Saved" or "Copied to clipboard".
+//
+// A window shows one toast at a time.
+// Further toasts are queued and shown after the current toast is dismissed.
+// A toast is dismissed when its timeout has passed, when the user taps it
+// and when the user presses the action button.
+// A toast does not block the window: taps outside of it reach the window content as usual.
+//
+// While toasts are shown, the content of the window is wrapped in a container,
+// which draws the toasts on top of it. The original content is restored afterwards.
+type Toast struct {
+	// Optional label of an action button, e.g. "Undo".
+	ActionLabel string
+
+	// Importance determines the background color of the toast.
+	// It uses the same colors as a [Badge].
+	Importance widget.Importance
+
+	// Optional callback when the user presses the action button.
+	OnAction func()
+
+	// Text of the message.
+	Text string
+
+	// Timeout after which the toast is dismissed automatically.
+	// [DefaultToastTimeout] is used when the timeout is zero.
+	// A negative timeout keeps the toast open until it is dismissed by the user.
+	Timeout time.Duration
+}
+
+// toasts keeps the queues of toasts for each window.
+var toasts = struct {
+	mu     sync.Mutex
+	queues map[fyne.Window]*toastQueue
+}{
+	queues: make(map[fyne.Window]*toastQueue),
+}
+
+type toastQueue struct {
+	content fyne.CanvasObject // original content of the window
+	current *toastView
+	layer   *fyne.Container // content of the window while toasts are shown
+	pending []Toast
+	slot    *fyne.Container // holds the current toast
+}
+
+// ShowToast shows toast t in window w.
+// When the window already shows a toast, t is shown after all previous toasts are dismissed.
+func ShowToast(t Toast, w fyne.Window) {
+	toasts.mu.Lock()
+	q, ok := toasts.queues[w]
+	if !ok {
+		q = &toastQueue{}
+		toasts.queues[w] = q
+	}
+	q.pending = append(q.pending, t)
+	busy := q.current != nil
+	toasts.mu.Unlock()
+	if !busy {
+		showNextToast(w)
+	}
+}
+
+// DismissToast dismisses the toast currently shown in window w, if any.
+func DismissToast(w fyne.Window) {
+	toasts.mu.Lock()
+	var o *toastView
+	if q, ok := toasts.queues[w]; ok {
+		o = q.current
+	}
+	toasts.mu.Unlock()
+	if o != nil {
+		dismissToast(w, o)
+	}
+}
+
+func showNextToast(w fyne.Window) {
+	toasts.mu.Lock()
+	q, ok := toasts.queues[w]
+	if !ok {
+		toasts.mu.Unlock()
+		return
+	}
+	if len(q.pending) == 0 {
+		delete(toasts.queues, w)
+		toasts.mu.Unlock()
+		if q.layer != nil && w.Content() == q.layer {
+			setContentKeepFocus(w, q.content)
+		}
+		return
+	}
+	t := q.pending[0]
+	q.pending = q.pending[1:]
+	var o *toastView
+	o = newToastView(t, func() {
+		dismissToast(w, o)
+	})
+	q.current = o
+	toasts.mu.Unlock()
+	if q.layer == nil || w.Content() != q.layer {
+		q.content = w.Content()
+		q.slot = container.NewCenter()
+		q.layer = container.NewStack(q.content, container.NewVBox(
+			layout.NewSpacer(),
+			container.NewPadded(q.slot),
+		))
+		setContentKeepFocus(w, q.layer)
+	}
+	q.slot.Objects = []fyne.CanvasObject{o}
+	q.slot.Refresh()
+	timeout := t.Timeout
+	if timeout == 0 {
+		timeout = DefaultToastTimeout
+	}
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			fyne.Do(func() {
+				dismissToast(w, o)
+			})
+		})
+		toasts.mu.Lock()
+		o.timer = timer
+		toasts.mu.Unlock()
+	}
+}
+
+// dismissToast dismisses toast o of window w and shows the next toast.
+// Nothing happens when o has already been dismissed.
+func dismissToast(w fyne.Window, o *toastView) {
+	toasts.mu.Lock()
+	q, ok := toasts.queues[w]
+	if !ok || q.current != o {
+		toasts.mu.Unlock()
+		return
+	}
+	q.current = nil
+	timer := o.timer
+	toasts.mu.Unlock()
+	if timer != nil {
+		timer.Stop()
+	}
+	if o.action != nil && w.Canvas().Focused() == o.action {
+		w.Canvas().Unfocus()
+	}
+	q.slot.Objects = nil
+	q.slot.Refresh()
+	showNextToast(w)
+	close(o.dismissed)
+}
+
+// setContentKeepFocus sets the content of window w and keeps the focus of the previous content.
+//
+// The previous content also keeps its visibility.
+// This is needed, because the desktop driver hides the previous content of a window,
+// which is still shown when it is wrapped by the new content.
+func setContentKeepFocus(w fyne.Window, content fyne.CanvasObject) {
+	c := w.Canvas()
+	focused := c.Focused()
+	previous := w.Content()
+	visible := previous != nil && previous.Visible()
+	w.SetContent(content)
+	if visible {
+		previous.Show()
+	}
+	if focused != nil {
+		c.Focus(focused)
+	}
+}
+
+// toastView is a widget showing a toast.
+type toastView struct {
+	widget.BaseWidget
+
+	action     *widget.Button
+	background *canvas.Rectangle
+	dismiss    func()
+	dismissed  chan struct{} // closed after the toast has been dismissed
+	importance widget.Importance
+	label      *canvas.Text
+	timer      *time.Timer
+}
+
+var _ fyne.Tappable = (*toastView)(nil)
+
+func newToastView(t Toast, dismiss func()) *toastView {
+	bg := canvas.NewRectangle(color.Transparent)
+	bg.CornerRadius = 10
+	o := &toastView{
+		background: bg,
+		dismiss:    dismiss,
+		dismissed:  make(chan struct{}),
+		importance: t.Importance,
+		label:      canvas.NewText(t.Text, color.Transparent),
+	}
+	if t.ActionLabel != "" {
+		o.action = widget.NewButton(t.ActionLabel, func() {
+			if t.OnAction != nil {
+				t.OnAction()
+			}
+			o.dismiss()
+		})
+	}
+	o.ExtendBaseWidget(o)
+	o.updateColors()
+	return o
+}
+
+// Tapped dismisses the toast.
+func (o *toastView) Tapped(_ *fyne.PointEvent) {
+	o.dismiss()
+}
+
+func (o *toastView) Refresh() {
+	o.updateColors()
+	o.BaseWidget.Refresh()
+}
+
+func (o *toastView) updateColors() {
+	th := o.Theme()
+	v := fyne.CurrentApp().Settings().ThemeVariant()
+	o.background.FillColor = th.Color(importanceColorName(o.importance), v)
+	o.background.Refresh()
+	o.label.Color = th.Color(importanceForegroundColorName(o.importance), v)
+	o.label.TextSize = th.Size(theme.SizeNameText)
+	o.label.Refresh()
+}
+
+func (o *toastView) CreateRenderer() fyne.WidgetRenderer {
+	p := o.Theme().Size(theme.SizeNameInnerPadding)
+	row := container.NewHBox(container.New(layout.NewCustomPaddedLayout(p, p, p, p), o.label))
+	if o.action != nil {
+		row.Add(o.action)
+	}
+	p = o.Theme().Size(theme.SizeNamePadding)
+	return widget.NewSimpleRenderer(container.NewStack(
+		o.background,
+		container.New(layout.NewCustomPaddedLayout(0, 0, p, p), row),
+	))
+}
+
+// importanceForegroundColorName returns the name of the theme color for text
+// on the background color of an importance.
+func importanceForegroundColorName(importance widget.Importance) fyne.ThemeColorName {
+	switch importance {
+	case widget.DangerImportance:
+		return theme.ColorNameForegroundOnError
+	case widget.HighImportance:
+		return theme.ColorNameForegroundOnPrimary
+	case widget.SuccessImportance:
+		return theme.ColorNameForegroundOnSuccess
+	case widget.WarningImportance:
+		return theme.ColorNameForegroundOnWarning
+	}
+	return theme.ColorNameForeground
+}
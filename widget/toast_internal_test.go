@@ -0,0 +1,150 @@
+package widget
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+// currentToast returns the toast currently shown in window w or nil.
+func currentToast(w fyne.Window) *toastView {
+	toasts.mu.Lock()
+	defer toasts.mu.Unlock()
+	q, ok := toasts.queues[w]
+	if !ok {
+		return nil
+	}
+	return q.current
+}
+
+// desktopWindow is a test window, which hides the previous content when setting new content
+// like the window of the desktop driver.
+type desktopWindow struct {
+	fyne.Window
+}
+
+func (w *desktopWindow) SetContent(content fyne.CanvasObject) {
+	if c := w.Window.Content(); c != nil {
+		c.Hide()
+	}
+	w.Window.SetContent(content)
+	if content != nil {
+		content.Show()
+	}
+}
+
+func TestToast(t *testing.T) {
+	test.NewTempApp(t)
+	t.Run("should show toast on top of content", func(t *testing.T) {
+		content := widget.NewLabel("Content")
+		w := test.NewWindow(content)
+		defer w.Close()
+		ShowToast(Toast{Text: "Saved", Timeout: -1}, w)
+		o := currentToast(w)
+		if assert.NotNil(t, o) {
+			assert.Equal(t, "Saved", o.label.Text)
+			assert.Nil(t, o.action)
+		}
+		assert.Nil(t, w.Canvas().Overlays().Top())
+		assert.NotSame(t, content, w.Content())
+		DismissToast(w)
+		assert.Nil(t, currentToast(w))
+		assert.Same(t, content, w.Content())
+	})
+	t.Run("should keep content visible with desktop driver", func(t *testing.T) {
+		content := widget.NewLabel("Content")
+		w := &desktopWindow{test.NewWindow(content)}
+		defer w.Close()
+		ShowToast(Toast{Text: "Saved", Timeout: -1}, w)
+		assert.True(t, content.Visible())
+		DismissToast(w)
+		assert.True(t, content.Visible())
+		assert.Same(t, content, w.Content())
+	})
+	t.Run("should show queued toasts one after another", func(t *testing.T) {
+		content := widget.NewLabel("Content")
+		w := test.NewWindow(content)
+		defer w.Close()
+		ShowToast(Toast{Text: "First", Timeout: -1}, w)
+		ShowToast(Toast{Text: "Second", Timeout: -1}, w)
+		assert.Equal(t, "First", currentToast(w).label.Text)
+		DismissToast(w)
+		assert.Equal(t, "Second", currentToast(w).label.Text)
+		DismissToast(w)
+		assert.Nil(t, currentToast(w))
+		assert.Same(t, content, w.Content())
+	})
+	t.Run("should dismiss toast when tapped", func(t *testing.T) {
+		w := test.NewWindow(widget.NewLabel("Content"))
+		defer w.Close()
+		ShowToast(Toast{Text: "Saved", Timeout: -1}, w)
+		test.Tap(currentToast(w))
+		assert.Nil(t, currentToast(w))
+	})
+	t.Run("should not absorb taps outside of toast", func(t *testing.T) {
+		var tapped bool
+		button := widget.NewButton("Button", func() {
+			tapped = true
+		})
+		w := test.NewWindow(button)
+		defer w.Close()
+		w.Resize(fyne.NewSize(300, 300))
+		ShowToast(Toast{Text: "Saved", Timeout: -1}, w)
+		test.TapCanvas(w.Canvas(), fyne.NewPos(20, 20))
+		assert.True(t, tapped)
+		assert.NotNil(t, currentToast(w))
+		DismissToast(w)
+	})
+	t.Run("should keep focus of content", func(t *testing.T) {
+		entry := widget.NewEntry()
+		w := test.NewWindow(entry)
+		defer w.Close()
+		w.Canvas().Focus(entry)
+		ShowToast(Toast{Text: "Saved", Timeout: -1}, w)
+		assert.Equal(t, entry, w.Canvas().Focused())
+		DismissToast(w)
+		assert.Equal(t, entry, w.Canvas().Focused())
+	})
+	t.Run("should run action and dismiss toast", func(t *testing.T) {
+		w := test.NewWindow(widget.NewLabel("Content"))
+		defer w.Close()
+		var called bool
+		ShowToast(Toast{Text: "Deleted", ActionLabel: "Undo", OnAction: func() {
+			called = true
+		}, Timeout: -1}, w)
+		test.Tap(currentToast(w).action)
+		assert.True(t, called)
+		assert.Nil(t, currentToast(w))
+	})
+	t.Run("should dismiss toast after timeout", func(t *testing.T) {
+		content := widget.NewLabel("Content")
+		w := test.NewWindow(content)
+		defer w.Close()
+		ShowToast(Toast{Text: "Saved", Timeout: 10 * time.Millisecond}, w)
+		o := currentToast(w)
+		if !assert.NotNil(t, o) {
+			return
+		}
+		select {
+		case <-o.dismissed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		assert.Nil(t, currentToast(w))
+		assert.Same(t, content, w.Content())
+	})
+	t.Run("should use badge colors for importance", func(t *testing.T) {
+		w := test.NewWindow(widget.NewLabel("Content"))
+		defer w.Close()
+		ShowToast(Toast{Text: "Failed", Importance: widget.DangerImportance, Timeout: -1}, w)
+		o := currentToast(w)
+		assert.Equal(t, theme.Color(theme.ColorNameError), o.background.FillColor)
+		assert.Equal(t, theme.Color(theme.ColorNameForegroundOnError), o.label.Color)
+		DismissToast(w)
+	})
+}